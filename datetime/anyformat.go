@@ -0,0 +1,627 @@
+package datetime
+
+/*
+Parsing timestamps in unknown formats.
+
+ParseAnyFormat splits the input into numbers, words and separators in a single pass,
+then reads date, clock and offset from the shape of the tokens, without trying
+layouts one by one. Recognized shapes include:
+
+	2021-01-02 15:04:05        2021/01/02T15:04:05.000Z    20210102T150405Z
+	02/Jan/2006:15:04:05 -0700 Jan 2 15:04:05              Mon Jan  2 15:04:05 UTC 2006
+	Mon, 02 Jan 2006 15:04:05 GMT                          January 2, 2006 3:04 PM
+	01/02/2006 15:04           02.01.2006                  2006-01-02 15:04:05,000
+	1609599845                 1609599845123               1609599845.123
+
+Numeric dates with the year last are ambiguous, "01/02/2006" is January 2nd in the
+United States and February 1st in most other places. A part greater than 12 must be
+the day, otherwise AnyFormatOptions.Order decides. Dates with dots are always day
+first. Two digit years are in [1969, 2068].
+
+Numbers alone are Unix times, in seconds for up to 10 digits, in milliseconds for 13,
+microseconds for 16 and nanoseconds for 19 digits. 8 and 14 digit numbers are compact
+dates, "20060102" and "20060102150405". 4 digit numbers like "2021" are rejected with
+ErrInvalidSyntax, they are years alone rather than Unix times.
+
+Times without an offset are in AnyFormatOptions.Zone, which is UTC by default. Dates
+without a year, like in syslog, are in AnyFormatOptions.DefaultYear.
+*/
+
+import (
+	"math"
+	"strings"
+	"time"
+)
+
+// DateOrder decides the order of ambiguous numeric dates
+type DateOrder int
+
+// AnyFormatOptions configures ParseAnyFormat
+type AnyFormatOptions struct {
+	// Order of month and day in numeric dates like "01/02/2006"
+	Order DateOrder
+	// DefaultYear is the year of dates without one, zero is the current year
+	DefaultYear int
+	// Zone of times without an offset, the zero value is UTC
+	Zone Zone
+}
+
+// anyToken is a number, a word or a separator
+type anyToken struct {
+	// kind is tokenNumber, tokenWord or the separator character
+	kind  byte
+	text  string
+	value int64
+}
+
+// anyParser reads fields from tokens
+type anyParser struct {
+	tokens []anyToken
+	pos    int
+	opts   *AnyFormatOptions
+
+	year, month, day     int
+	hour, minute, second int
+	nsec                 int
+	offset               int
+	hasYear, hasOffset   bool
+}
+
+const (
+	// MonthFirst reads "01/02/2006" as January 2nd
+	MonthFirst DateOrder = iota
+	// DayFirst reads "01/02/2006" as February 1st
+	DayFirst
+)
+
+const (
+	tokenNumber = '0'
+	tokenWord   = 'a'
+	tokenSpace  = ' '
+	// Inputs with more tokens are rejected
+	maxAnyTokens = 32
+	compactDate  = len("20060102")
+	compactFull  = len("20060102150405")
+)
+
+// ParseAnyFormat parses a timestamp in any of the recognized formats, in UTC
+func ParseAnyFormat(s string) (Time, error) {
+	var opts AnyFormatOptions
+	return opts.Parse(s)
+}
+
+/*
+Parse parses a timestamp in any of the recognized formats.
+
+Errors are either ErrInvalidSyntax or ErrOutOfRange.
+*/
+func (o *AnyFormatOptions) Parse(s string) (Time, error) {
+	var buf [maxAnyTokens]anyToken
+
+	tokens, ok := lexAny(buf[:0], s)
+	if !ok || len(tokens) == 0 {
+		return Time{}, ErrInvalidSyntax
+	}
+
+	p := anyParser{tokens: tokens, opts: o}
+	if t, ok, err := p.parseEpoch(); ok {
+		return t, err
+	}
+
+	if err := p.parse(); err != nil {
+		return Time{}, err
+	}
+
+	return p.time()
+}
+
+/*
+lexAny splits s into tokens. Runs of spaces are a single tokenSpace, runs of ASCII
+letters are words and runs of digits are numbers.
+*/
+func lexAny(tokens []anyToken, s string) ([]anyToken, bool) {
+	for i := 0; i < len(s); {
+		if len(tokens) == cap(tokens) {
+			return nil, false
+		}
+
+		start, c := i, s[i]
+		tok := anyToken{kind: c}
+
+		switch {
+		case isDigit(c):
+			tok.kind = tokenNumber
+			for i < len(s) && isDigit(s[i]) {
+				d := int64(s[i] - '0')
+				if tok.value > (math.MaxInt64-d)/10 {
+					return nil, false
+				}
+
+				tok.value = tok.value*10 + d
+				i++
+			}
+		case isASCIILetter(c):
+			tok.kind = tokenWord
+			for i < len(s) && isASCIILetter(s[i]) {
+				i++
+			}
+		case c == ' ' || c == '\t':
+			tok.kind = tokenSpace
+			for i < len(s) && (s[i] == ' ' || s[i] == '\t') {
+				i++
+			}
+		default:
+			i++
+		}
+
+		tok.text = s[start:i]
+		tokens = append(tokens, tok)
+	}
+
+	return tokens, true
+}
+
+func isASCIILetter(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// peek returns kind of the token at offset from the current one, zero at the end
+func (p *anyParser) peek(offset int) byte {
+	if p.pos+offset >= len(p.tokens) {
+		return 0
+	}
+	return p.tokens[p.pos+offset].kind
+}
+
+// next returns the current token and moves past it
+func (p *anyParser) next() anyToken {
+	tok := p.tokens[p.pos]
+	p.pos++
+	return tok
+}
+
+// skip moves past the current token if it is of kind
+func (p *anyParser) skip(kind byte) bool {
+	if p.peek(0) != kind {
+		return false
+	}
+	p.pos++
+	return true
+}
+
+// digits returns number of digits of the token at offset, zero if it isn't a number
+func (p *anyParser) digits(offset int) int {
+	if p.peek(offset) != tokenNumber {
+		return 0
+	}
+	return len(p.tokens[p.pos+offset].text)
+}
+
+// parseEpoch parses a number alone as a Unix time, ok is false for other inputs
+//
+//nolint:mnd // digits of Unix times in each unit
+func (p *anyParser) parseEpoch() (Time, bool, error) {
+	sign := int64(1)
+	if p.peek(0) == '-' {
+		sign = -1
+		p.pos++
+	}
+
+	n := p.digits(0)
+	if n == 0 || n == compactDate || n == compactFull {
+		p.pos = 0
+		return Time{}, false, nil
+	}
+
+	v := sign * p.next().value
+	switch {
+	case len(p.tokens) == p.pos+2 && p.peek(0) == '.' && p.digits(1) > 0 && n <= 10:
+		// Seconds with a fraction
+		p.pos++
+		return Unix(v, sign*int64(fractionNanos(p.next().text))), true, nil
+	case len(p.tokens) != p.pos:
+		p.pos = 0
+		return Time{}, false, nil
+	case n == 4 && sign > 0:
+		// More likely a year alone than a time in the first hour of 1970
+		return Time{}, true, ErrInvalidSyntax
+	case n <= 10:
+		return Unix(v, 0), true, nil
+	case n == 13:
+		return UnixMilli(v), true, nil
+	case n == 16:
+		return UnixMicro(v), true, nil
+	case n == 19:
+		return Unix(0, v), true, nil
+	default:
+		return Time{}, true, ErrInvalidSyntax
+	}
+}
+
+func (p *anyParser) parse() error {
+	// Weekday names are ignored
+	if p.peek(0) == tokenWord && weekdayByName(p.tokens[0].text) {
+		p.pos++
+		p.skip(',')
+		p.skip(tokenSpace)
+	}
+
+	if err := p.parseDate(); err != nil {
+		return err
+	}
+
+	// Separator between date and clock
+	switch {
+	case p.peek(0) == tokenWord && strings.EqualFold(p.tokens[p.pos].text, "T"):
+		p.pos++
+	case p.peek(0) == ':' || p.peek(0) == ',':
+		p.pos++
+		p.skip(tokenSpace)
+	default:
+		p.skip(tokenSpace)
+	}
+
+	if p.digits(0) > 0 {
+		if err := p.parseClock(); err != nil {
+			return err
+		}
+	}
+
+	p.skip(tokenSpace)
+	if err := p.parseOffset(); err != nil {
+		return err
+	}
+
+	// Year after the clock, like in "Jan  2 15:04:05 UTC 2006"
+	p.skip(tokenSpace)
+	if !p.hasYear && p.digits(0) == len("2006") {
+		p.year, p.hasYear = int(p.next().value), true
+	}
+
+	if p.pos != len(p.tokens) {
+		return ErrInvalidSyntax
+	}
+
+	return nil
+}
+
+// parseDate reads the date
+//
+//nolint:mnd // digits of date fields
+func (p *anyParser) parseDate() error {
+	switch n := p.digits(0); {
+	case n == compactDate || n == compactFull:
+		return p.parseCompact()
+	case n == 4:
+		return p.parseYearFirst()
+	case n == 1 || n == 2:
+		return p.parseDayOrMonthFirst()
+	case n == 0 && p.peek(0) == tokenWord:
+		return p.parseMonthName()
+	default:
+		return ErrInvalidSyntax
+	}
+}
+
+// parseCompact reads "20060102" and "20060102150405"
+//
+//nolint:mnd // field positions of compact dates
+func (p *anyParser) parseCompact() error {
+	tok := p.next()
+
+	// Pairs of digits, the year is two of them
+	var fields [compactFull / 2]int
+	for i := range len(tok.text) / 2 {
+		fields[i], _ = parseDigits(tok.text[i*2 : i*2+2])
+	}
+
+	p.year, p.hasYear = fields[0]*100+fields[1], true
+	p.month, p.day = fields[2], fields[3]
+	p.hour, p.minute, p.second = fields[4], fields[5], fields[6]
+
+	return nil
+}
+
+// parseYearFirst reads "2006-01-02", "2006/01/02" and "2006.01.02"
+func (p *anyParser) parseYearFirst() error {
+	p.year, p.hasYear = int(p.next().value), true
+
+	sep := p.peek(0)
+	if sep != '-' && sep != '/' && sep != '.' {
+		return ErrInvalidSyntax
+	}
+	p.pos++
+
+	if p.digits(0) == 0 || p.digits(0) > 2 || p.peek(1) != sep || p.digits(2) == 0 ||
+		p.digits(2) > 2 {
+		return ErrInvalidSyntax
+	}
+
+	p.month = int(p.next().value)
+	p.pos++
+	p.day = int(p.next().value)
+
+	return nil
+}
+
+// parseDayOrMonthFirst reads "02/Jan/2006", "2 Jan 2006" and numeric dates like
+// "01/02/2006"
+//
+//nolint:mnd // digits of years
+func (p *anyParser) parseDayOrMonthFirst() error {
+	first := int(p.next().value)
+
+	sep := p.peek(0)
+	if sep != '-' && sep != '/' && sep != '.' && sep != tokenSpace {
+		return ErrInvalidSyntax
+	}
+	p.pos++
+
+	second := 0
+	switch {
+	case p.peek(0) == tokenWord:
+		second = monthByName(p.next().text)
+		if second == 0 {
+			return ErrInvalidSyntax
+		}
+		p.day, p.month = first, second
+	case p.digits(0) == 1 || p.digits(0) == 2:
+		second = int(p.next().value)
+		p.resolveOrder(first, second, sep)
+	default:
+		return ErrInvalidSyntax
+	}
+
+	if !p.skip(sep) {
+		return ErrInvalidSyntax
+	}
+
+	switch p.digits(0) {
+	case 2:
+		p.year = pivotYear(int(p.next().value))
+	case 4:
+		p.year = int(p.next().value)
+	default:
+		return ErrInvalidSyntax
+	}
+	p.hasYear = true
+
+	return nil
+}
+
+// resolveOrder sets month and day of a numeric date
+//
+//nolint:mnd // months in a year
+func (p *anyParser) resolveOrder(first, second int, sep byte) {
+	dayFirst := p.opts.Order == DayFirst
+	switch {
+	case sep == '.' || first > 12:
+		dayFirst = true
+	case second > 12:
+		dayFirst = false
+	}
+
+	if dayFirst {
+		p.day, p.month = first, second
+	} else {
+		p.month, p.day = first, second
+	}
+}
+
+// parseMonthName reads "Jan 2", "January 2, 2006" and "Jan 2 2006"
+//
+//nolint:mnd // digits of fields
+func (p *anyParser) parseMonthName() error {
+	p.month = monthByName(p.next().text)
+	if p.month == 0 || !p.skip(tokenSpace) {
+		return ErrInvalidSyntax
+	}
+
+	if n := p.digits(0); n != 1 && n != 2 {
+		return ErrInvalidSyntax
+	}
+	p.day = int(p.next().value)
+
+	// Year follows unless it is the hour of the clock
+	comma := p.skip(',')
+	if p.peek(0) == tokenSpace && p.digits(1) == 4 && p.peek(2) != ':' {
+		p.pos++
+		p.year, p.hasYear = int(p.next().value), true
+	} else if comma {
+		return ErrInvalidSyntax
+	}
+
+	return nil
+}
+
+// parseClock reads "15:04", "15:04:05", "15:04:05.000" and "150405" with am or pm
+//
+//nolint:mnd // digits of clock fields
+func (p *anyParser) parseClock() error {
+	if n := p.digits(0); n == 4 || n == 6 {
+		tok := p.next().text
+		p.hour, _ = parseDigits(tok[0:2])
+		p.minute, _ = parseDigits(tok[2:4])
+		if n == 6 {
+			p.second, _ = parseDigits(tok[4:6])
+		}
+	} else if err := p.parseColonClock(); err != nil {
+		return err
+	}
+
+	if (p.peek(0) == '.' || p.peek(0) == ',') && p.digits(1) > 0 {
+		p.pos++
+		p.nsec = fractionNanos(p.next().text)
+	}
+
+	return p.parseMeridiem()
+}
+
+//nolint:mnd // digits of clock fields
+func (p *anyParser) parseColonClock() error {
+	if n := p.digits(0); n != 1 && n != 2 || p.peek(1) != ':' || p.digits(2) != 2 {
+		return ErrInvalidSyntax
+	}
+
+	p.hour = int(p.next().value)
+	p.pos++
+	p.minute = int(p.next().value)
+
+	if p.peek(0) == ':' && p.digits(1) == 2 {
+		p.pos++
+		p.second = int(p.next().value)
+	}
+
+	return nil
+}
+
+// parseMeridiem reads "am" or "pm" after the clock
+func (p *anyParser) parseMeridiem() error {
+	i := 0
+	if p.peek(0) == tokenSpace {
+		i = 1
+	}
+
+	if p.peek(i) != tokenWord {
+		return nil
+	}
+
+	pm := strings.EqualFold(p.tokens[p.pos+i].text, "PM")
+	if !pm && !strings.EqualFold(p.tokens[p.pos+i].text, "AM") {
+		return nil
+	}
+
+	if p.hour < 1 || p.hour > hoursPerHalf {
+		return ErrOutOfRange
+	}
+
+	p.hour %= hoursPerHalf
+	if pm {
+		p.hour += hoursPerHalf
+	}
+	p.pos += i + 1
+
+	return nil
+}
+
+// parseOffset reads "Z", "UTC", "GMT", "+07", "+0700" and "+07:00"
+//
+//nolint:mnd // digits of offsets
+func (p *anyParser) parseOffset() error {
+	switch p.peek(0) {
+	case tokenWord:
+		text := p.tokens[p.pos].text
+		if strings.EqualFold(text, "Z") || strings.EqualFold(text, "UTC") ||
+			strings.EqualFold(text, "GMT") {
+			p.pos++
+			p.hasOffset = true
+		}
+		return nil
+	case '+', '-':
+	default:
+		return nil
+	}
+
+	sign := 1
+	if p.next().kind == '-' {
+		sign = -1
+	}
+
+	var hh, mm int
+	switch p.digits(0) {
+	case 2:
+		hh = int(p.next().value)
+		if p.peek(0) == ':' && p.digits(1) == 2 {
+			p.pos++
+			mm = int(p.next().value)
+		}
+	case 4:
+		v := int(p.next().value)
+		hh, mm = v/100, v%100
+	default:
+		return ErrInvalidSyntax
+	}
+
+	if hh > 23 || mm > 59 {
+		return ErrOutOfRange
+	}
+
+	p.offset = sign * (hh*secondsPerHour + mm*secondsPerMinute)
+	p.hasOffset = true
+
+	return nil
+}
+
+// time validates the fields and returns the time
+//
+//nolint:mnd // ranges of clock fields
+func (p *anyParser) time() (Time, error) {
+	if !p.hasYear {
+		p.year = p.opts.DefaultYear
+		if p.year == 0 {
+			p.year = Now().LocalIn(p.opts.Zone).Date.Year
+		}
+	}
+
+	if p.month < 1 || p.month > 12 || p.day < 1 ||
+		p.day > daysIn(Month(p.month), int64(p.year)) || p.hour > 23 || p.minute > 59 ||
+		p.second > 59 {
+		return Time{}, ErrOutOfRange
+	}
+
+	wall := LocalDateTime{
+		Date: LocalDate{Year: p.year, Month: Month(p.month), Day: p.day},
+		Time: TimeOfDay{
+			Hour:       p.hour,
+			Minute:     p.minute,
+			Second:     p.second,
+			Nanosecond: p.nsec,
+		},
+	}
+
+	if p.hasOffset {
+		return wall.utc().Add(-Seconds(int64(p.offset))), nil
+	}
+
+	return wall.In(p.opts.Zone, ShiftForward, PreferEarlier)
+}
+
+// fractionNanos returns nanoseconds of fraction digits, extra digits are dropped
+func fractionNanos(digits string) int {
+	nsec := 0
+	for i := range maxFracDigits {
+		nsec *= 10
+		if i < len(digits) {
+			nsec += int(digits[i] - '0')
+		}
+	}
+	return nsec
+}
+
+// monthByName returns the month for an English name or abbreviation, or zero
+func monthByName(s string) int {
+	if strings.EqualFold(s, "Sept") {
+		return int(time.September)
+	}
+
+	for m := time.January; m <= time.December; m++ {
+		if name := m.String(); strings.EqualFold(s, name) ||
+			strings.EqualFold(s, name[:nameAbbrevLen]) {
+			return int(m)
+		}
+	}
+
+	return 0
+}
+
+// weekdayByName reports whether s is an English weekday name or abbreviation
+func weekdayByName(s string) bool {
+	for d := range Weekday(daysPerWeek) {
+		if name := d.String(); strings.EqualFold(s, name) ||
+			strings.EqualFold(s, name[:nameAbbrevLen]) {
+			return true
+		}
+	}
+
+	return false
+}
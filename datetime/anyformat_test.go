@@ -0,0 +1,176 @@
+package datetime_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/ram-nad/go-utils/datetime"
+)
+
+func TestParseAnyFormat(t *testing.T) {
+	tests := []struct {
+		s        string
+		expected datetime.Time
+	}{
+		{"2006-01-02", datetime.Date(2006, 1, 2, 0, 0, 0, 0)},
+		{"2006-01-02 15:04:05", datetime.Date(2006, 1, 2, 15, 4, 5, 0)},
+		{"2006-01-02T15:04:05Z", datetime.Date(2006, 1, 2, 15, 4, 5, 0)},
+		{"2006/01/02T15:04:05.000Z", datetime.Date(2006, 1, 2, 15, 4, 5, 0)},
+		{
+			"2006-01-02T15:04:05.123456789+07:00",
+			datetime.Date(2006, 1, 2, 8, 4, 5, 123456789),
+		},
+		{
+			"2006-01-02 15:04:05.1234567891",
+			datetime.Date(2006, 1, 2, 15, 4, 5, 123456789),
+		},
+		{"2006-01-02 15:04:05,250", datetime.Date(2006, 1, 2, 15, 4, 5, 250_000_000)},
+		{"2006-01-02 15:04 -0130", datetime.Date(2006, 1, 2, 16, 34, 0, 0)},
+		{"2006-01-02 15:04:05 +07", datetime.Date(2006, 1, 2, 8, 4, 5, 0)},
+		{"2006.01.02", datetime.Date(2006, 1, 2, 0, 0, 0, 0)},
+		{"20060102", datetime.Date(2006, 1, 2, 0, 0, 0, 0)},
+		{"20060102150405", datetime.Date(2006, 1, 2, 15, 4, 5, 0)},
+		{"20060102T150405Z", datetime.Date(2006, 1, 2, 15, 4, 5, 0)},
+		{"20060102 1504", datetime.Date(2006, 1, 2, 15, 4, 0, 0)},
+		{"02/Jan/2006:15:04:05 -0700", datetime.Date(2006, 1, 2, 22, 4, 5, 0)},
+		{"Mon Jan  2 15:04:05 UTC 2006", datetime.Date(2006, 1, 2, 15, 4, 5, 0)},
+		{"Mon, 02 Jan 2006 15:04:05 GMT", datetime.Date(2006, 1, 2, 15, 4, 5, 0)},
+		{"Monday, 02-Jan-06 15:04:05 GMT", datetime.Date(2006, 1, 2, 15, 4, 5, 0)},
+		{"January 2, 2006 3:04 PM", datetime.Date(2006, 1, 2, 15, 4, 0, 0)},
+		{"january 2, 2006 12:04 am", datetime.Date(2006, 1, 2, 0, 4, 0, 0)},
+		{"Sept 2 2006", datetime.Date(2006, 9, 2, 0, 0, 0, 0)},
+		{"2 Jan 2006 15:04", datetime.Date(2006, 1, 2, 15, 4, 0, 0)},
+		{"01/02/2006 15:04", datetime.Date(2006, 1, 2, 15, 4, 0, 0)},
+		{"1/2/06", datetime.Date(2006, 1, 2, 0, 0, 0, 0)},
+		{"1/2/70", datetime.Date(1970, 1, 2, 0, 0, 0, 0)},
+		// A part greater than 12 must be the day
+		{"13/01/2006", datetime.Date(2006, 1, 13, 0, 0, 0, 0)},
+		{"01/13/2006", datetime.Date(2006, 1, 13, 0, 0, 0, 0)},
+		// Dots are day first
+		{"02.01.2006", datetime.Date(2006, 1, 2, 0, 0, 0, 0)},
+		{"29.02.2024 23:59:59", datetime.Date(2024, 2, 29, 23, 59, 59, 0)},
+		// Unix times
+		{"0", datetime.Unix(0, 0)},
+		{"1609599845", datetime.Unix(1609599845, 0)},
+		{"-86400", datetime.Unix(-86400, 0)},
+		{"1609599845.5", datetime.Unix(1609599845, 500_000_000)},
+		{"-1.5", datetime.Unix(-2, 500_000_000)},
+		{"1609599845123", datetime.UnixMilli(1609599845123)},
+		{"1609599845123456", datetime.Unix(1609599845, 123456000)},
+		{"9999999999999999", datetime.Unix(9999999999, 999999000)},
+		{"1609599845123456789", datetime.Unix(1609599845, 123456789)},
+	}
+
+	for _, tc := range tests {
+		got, err := datetime.ParseAnyFormat(tc.s)
+		if err != nil || !got.Equal(tc.expected) {
+			t.Errorf(
+				"ParseAnyFormat(%q) = %v, %v, want %v", tc.s, got, err, tc.expected,
+			)
+		}
+	}
+}
+
+func TestParseAnyFormatOptions(t *testing.T) {
+	opts := datetime.AnyFormatOptions{
+		Order:       datetime.DayFirst,
+		DefaultYear: 2020,
+		Zone:        mustLoadZone(t, "America/New_York"),
+	}
+
+	tests := []struct {
+		s        string
+		expected datetime.Time
+	}{
+		{"01/02/2006", datetime.Date(2006, 2, 1, 5, 0, 0, 0)},
+		{"01/13/2006", datetime.Date(2006, 1, 13, 5, 0, 0, 0)},
+		{"Feb 29 12:30:00", datetime.Date(2020, 2, 29, 17, 30, 0, 0)},
+		{"Jul  4 12:30:00", datetime.Date(2020, 7, 4, 16, 30, 0, 0)},
+		{"2020-07-04T12:30:00Z", datetime.Date(2020, 7, 4, 12, 30, 0, 0)},
+		// Skipped by daylight saving time
+		{"2021-03-14 02:30", datetime.Date(2021, 3, 14, 7, 30, 0, 0)},
+		// Unix times aren't in the zone
+		{"1609599845", datetime.Unix(1609599845, 0)},
+	}
+
+	for _, tc := range tests {
+		got, err := opts.Parse(tc.s)
+		if err != nil || !got.Equal(tc.expected) {
+			t.Errorf("Parse(%q) = %v, %v, want %v", tc.s, got, err, tc.expected)
+		}
+	}
+}
+
+func TestParseAnyFormatDefaultYear(t *testing.T) {
+	got, err := datetime.ParseAnyFormat("Mar  5 10:00:00")
+	if err != nil {
+		t.Fatalf("ParseAnyFormat() = %v", err)
+	}
+
+	if year := datetime.Now().Year(); got.Year() != year {
+		t.Errorf("ParseAnyFormat().Year() = %v, want %v", got.Year(), year)
+	}
+}
+
+func TestParseAnyFormatErrors(t *testing.T) {
+	tests := []struct {
+		s   string
+		err error
+	}{
+		{"", datetime.ErrInvalidSyntax},
+		{"   ", datetime.ErrInvalidSyntax},
+		{"yesterday", datetime.ErrInvalidSyntax},
+		{"16095998451", datetime.ErrInvalidSyntax},
+		{"99999999999999999999", datetime.ErrInvalidSyntax},
+		{"2006-01-02 15:04:05 PST", datetime.ErrInvalidSyntax},
+		{"2006-01/02", datetime.ErrInvalidSyntax},
+		{"2006-001-02", datetime.ErrInvalidSyntax},
+		{"02/Foo/2006", datetime.ErrInvalidSyntax},
+		{"January 2, 15:04", datetime.ErrInvalidSyntax},
+		{"01/02/006", datetime.ErrInvalidSyntax},
+		{"2006-01-02 15", datetime.ErrInvalidSyntax},
+		{"2006-01-02 15:04 +7", datetime.ErrInvalidSyntax},
+		{"2006-01-02 1:2:3:4:5:6:7:8:9:0:1:2:3:4:5:6:7", datetime.ErrInvalidSyntax},
+		{"2006-13-02", datetime.ErrOutOfRange},
+		{"2006-02-29", datetime.ErrOutOfRange},
+		{"13/13/2006", datetime.ErrOutOfRange},
+		{"2006-01-02 24:00", datetime.ErrOutOfRange},
+		{"2006-01-02 13:00 PM", datetime.ErrOutOfRange},
+		{"2006-01-02 12:00 +2400", datetime.ErrOutOfRange},
+		{"20061302", datetime.ErrOutOfRange},
+		// Years alone aren't Unix times
+		{"2021", datetime.ErrInvalidSyntax},
+	}
+
+	for _, tc := range tests {
+		if _, err := datetime.ParseAnyFormat(tc.s); !errors.Is(err, tc.err) {
+			t.Errorf("ParseAnyFormat(%q) = %v, want %v", tc.s, err, tc.err)
+		}
+	}
+}
+
+func BenchmarkParseAnyFormat(b *testing.B) {
+	for b.Loop() {
+		_, _ = datetime.ParseAnyFormat("02/Jan/2006:15:04:05 -0700")
+	}
+}
+
+func ExampleParseAnyFormat() {
+	for _, s := range []string{
+		"2021-08-09T16:07:03.5+02:00",
+		"09/Aug/2021:16:07:03 +0000",
+		"Mon, 09 Aug 2021 16:07:03 GMT",
+		"August 9, 2021 4:07 PM",
+		"1628525223",
+	} {
+		t, _ := datetime.ParseAnyFormat(s)
+		_, _ = fmt.Println(t.Format(datetime.RFC3339Nano))
+	}
+	// Output:
+	// 2021-08-09T14:07:03.5Z
+	// 2021-08-09T16:07:03Z
+	// 2021-08-09T16:07:03Z
+	// 2021-08-09T16:07:00Z
+	// 2021-08-09T16:07:03Z
+}
@@ -0,0 +1,105 @@
+package datetime
+
+/*
+Calendar computations on the proleptic Gregorian calendar, working directly
+with days since Unix epoch to avoid the overhead of time.Time.
+*/
+
+import "time"
+
+const (
+	secondsPerMinute = 60
+	secondsPerHour   = 60 * secondsPerMinute
+	secondsPerDay    = 24 * secondsPerHour
+)
+
+// floorDiv returns a / b rounded towards negative infinity, b must be positive
+func floorDiv(a, b int64) int64 {
+	q := a / b
+	if a%b < 0 {
+		q--
+	}
+	return q
+}
+
+/*
+civilFromDays converts days since 1970-01-01 to year, month and day of the
+proleptic Gregorian calendar.
+
+See http://howardhinnant.github.io/date_algorithms.html#civil_from_days
+*/
+//nolint:mnd // constants of the calendar algorithm
+func civilFromDays(days int64) (year int64, month int, day int) {
+	z := days + 719468
+	era := floorDiv(z, 146097)
+	doe := z - era*146097                                  // [0, 146096]
+	yoe := (doe - doe/1460 + doe/36524 - doe/146096) / 365 // [0, 399]
+	doy := doe - (365*yoe + yoe/4 - yoe/100)               // [0, 365]
+	mp := (5*doy + 2) / 153                                // [0, 11], March is 0
+	day = int(doy - (153*mp+2)/5 + 1)
+
+	year = yoe + era*400
+	if mp < 10 {
+		month = int(mp + 3)
+	} else {
+		month = int(mp - 9)
+		year++
+	}
+
+	return year, month, day
+}
+
+/*
+daysFromCivil converts year, month and day of the proleptic Gregorian calendar
+to days since 1970-01-01. It is the inverse of civilFromDays.
+
+See http://howardhinnant.github.io/date_algorithms.html#days_from_civil
+*/
+//nolint:mnd // constants of the calendar algorithm
+func daysFromCivil(year int64, month int, day int) int64 {
+	if month <= 2 {
+		year--
+	}
+
+	era := floorDiv(year, 400)
+	yoe := year - era*400 // [0, 399]
+	mp := int64(month+9) % 12
+	doy := (153*mp+2)/5 + int64(day) - 1   // [0, 365]
+	doe := yoe*365 + yoe/4 - yoe/100 + doy // [0, 146096]
+
+	return era*146097 + doe - 719468
+}
+
+// isLeapYear reports whether year is a leap year
+//
+//nolint:mnd // leap year rules
+func isLeapYear(year int64) bool {
+	return year%4 == 0 && (year%100 != 0 || year%400 == 0)
+}
+
+// daysIn returns number of days in month of the year
+//
+//nolint:mnd // days in months
+func daysIn(month Month, year int64) int {
+	switch month {
+	case time.February:
+		if isLeapYear(year) {
+			return 29
+		}
+		return 28
+	case time.April, time.June, time.September, time.November:
+		return 30
+	default:
+		return 31
+	}
+}
+
+// IsLeapYear reports whether year is a leap year in the proleptic Gregorian calendar
+func IsLeapYear(year int) bool {
+	return isLeapYear(int64(year))
+}
+
+// DaysInMonth returns number of days in month of the year
+func DaysInMonth(year int, month Month) int {
+	return daysIn(month, int64(year))
+}
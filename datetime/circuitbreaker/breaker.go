@@ -0,0 +1,358 @@
+/*
+Package circuitbreaker stops calling a failing dependency until it has had time to
+recover.
+
+A Breaker starts closed and lets every call through, counting outcomes in a rolling
+window made of Buckets buckets of BucketSize each. It opens once either threshold is
+reached:
+
+	ConsecutiveFailures  that many failures in a row
+	FailureRatio         failures / calls in the window, once MinRequests were made
+
+An open breaker rejects calls with ErrOpen. After CoolDown it becomes half-open and
+lets HalfOpenRequests trial calls through, it closes again if they all succeed and
+opens for another CoolDown on the first failure.
+
+	breaker := circuitbreaker.New(circuitbreaker.Config{
+		BucketSize:          datetime.Seconds(1),
+		Buckets:             10,
+		ConsecutiveFailures: 5,
+		FailureRatio:        0.5,
+		MinRequests:         20,
+		CoolDown:            datetime.Seconds(30),
+	})
+
+	err := breaker.Do(func() error {
+		return client.Call(ctx, request)
+	})
+
+Time is read from Config.Clock, so tests can move it with a datetime.ManualClock.
+*/
+package circuitbreaker
+
+import (
+	"errors"
+	"math"
+	"sync"
+
+	"github.com/ram-nad/go-utils/datetime"
+)
+
+// State is the state of a Breaker
+type State int
+
+// Config configures a Breaker, thresholds which are zero are disabled
+type Config struct {
+	// BucketSize is the length of a bucket of the rolling window
+	BucketSize datetime.Duration
+	// Buckets is the number of buckets in the rolling window
+	Buckets int
+	// ConsecutiveFailures opens the breaker after that many failures in a row
+	ConsecutiveFailures int
+	// FailureRatio opens the breaker when this fraction of calls in the window failed
+	FailureRatio float64
+	// MinRequests is the number of calls in the window before FailureRatio applies
+	MinRequests int
+	// CoolDown is how long the breaker stays open
+	CoolDown datetime.Duration
+	// HalfOpenRequests is the number of trial calls when half-open, zero means one
+	HalfOpenRequests int
+	// OnStateChange is called on every state change, outside of any lock
+	OnStateChange func(from, to State, at datetime.ClockTime)
+	// Clock is the source of time, nil means datetime.SystemClock
+	Clock datetime.Clock
+}
+
+// Counts are outcomes of calls in the rolling window
+type Counts struct {
+	Requests            int
+	Failures            int
+	ConsecutiveFailures int
+}
+
+// Breaker is a circuit breaker, it is safe for concurrent use
+type Breaker struct {
+	mu     sync.Mutex
+	config Config
+	state  State
+	// generation changes with every state change, outcomes of calls allowed in an
+	// earlier generation are ignored
+	generation uint64
+	buckets    []bucket
+	// consecutive is the number of failures in a row
+	consecutive int
+	// openedAt is when the breaker last opened
+	openedAt datetime.ClockTime
+	// admitted and succeeded count trial calls when half-open
+	admitted, succeeded int
+	// changes are state changes not yet passed to OnStateChange
+	changes []change
+}
+
+// bucket counts outcomes of calls in a period of BucketSize
+type bucket struct {
+	// index is number of the period since time zero
+	index     int64
+	successes int
+	failures  int
+}
+
+// change is a state change to report
+type change struct {
+	from, to State
+	at       datetime.ClockTime
+}
+
+const (
+	// Closed lets all calls through
+	Closed State = iota
+	// Open rejects all calls
+	Open
+	// HalfOpen lets a limited number of trial calls through
+	HalfOpen
+)
+
+var (
+	// ErrOpen is returned for calls rejected by an open breaker
+	ErrOpen = errors.New("circuitbreaker: breaker is open")
+	// ErrTooManyRequests is returned for calls over the limit of a half-open breaker
+	ErrTooManyRequests = errors.New("circuitbreaker: too many requests while half-open")
+)
+
+// String returns name of the state
+func (s State) String() string {
+	switch s {
+	case Closed:
+		return "closed"
+	case Open:
+		return "open"
+	case HalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+/*
+New creates a closed breaker.
+
+New panics if BucketSize or Buckets is not positive.
+*/
+func New(config Config) *Breaker {
+	if config.BucketSize <= 0 || config.Buckets <= 0 {
+		panic("circuitbreaker: non-positive bucket size or count for New")
+	}
+
+	if config.HalfOpenRequests <= 0 {
+		config.HalfOpenRequests = 1
+	}
+
+	if config.Clock == nil {
+		config.Clock = datetime.SystemClock{}
+	}
+
+	b := &Breaker{config: config, buckets: make([]bucket, config.Buckets)}
+	b.resetWindow()
+
+	return b
+}
+
+// State returns the current state
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.unlock()
+
+	b.update(b.config.Clock.Now())
+	return b.state
+}
+
+// Counts returns outcomes of calls in the rolling window
+func (b *Breaker) Counts() Counts {
+	b.mu.Lock()
+	defer b.unlock()
+
+	successes, failures := b.window(b.config.Clock.Now())
+	return Counts{
+		Requests:            successes + failures,
+		Failures:            failures,
+		ConsecutiveFailures: b.consecutive,
+	}
+}
+
+/*
+Allow asks to make a call, done must be called with its outcome.
+
+The error is ErrOpen or ErrTooManyRequests if the call is rejected, done is nil then.
+*/
+func (b *Breaker) Allow() (func(success bool), error) {
+	b.mu.Lock()
+	defer b.unlock()
+
+	b.update(b.config.Clock.Now())
+
+	switch b.state {
+	case Open:
+		return nil, ErrOpen
+	case HalfOpen:
+		if b.admitted >= b.config.HalfOpenRequests {
+			return nil, ErrTooManyRequests
+		}
+		b.admitted++
+	case Closed:
+	}
+
+	generation := b.generation
+	return func(success bool) {
+		b.mu.Lock()
+		defer b.unlock()
+
+		if b.generation == generation {
+			b.record(success, b.config.Clock.Now())
+		}
+	}, nil
+}
+
+// Do calls fn if the breaker allows it, fn failed if it returned an error
+func (b *Breaker) Do(fn func() error) error {
+	done, err := b.Allow()
+	if err != nil {
+		return err
+	}
+
+	err = fn()
+	done(err == nil)
+
+	return err
+}
+
+// unlock unlocks the breaker, then reports state changes
+func (b *Breaker) unlock() {
+	changes := b.changes
+	b.changes = nil
+	b.mu.Unlock()
+
+	if b.config.OnStateChange == nil {
+		return
+	}
+
+	for _, c := range changes {
+		b.config.OnStateChange(c.from, c.to, c.at)
+	}
+}
+
+// setState moves to state to, which starts a new generation
+func (b *Breaker) setState(to State, now datetime.ClockTime) {
+	b.changes = append(b.changes, change{from: b.state, to: to, at: now})
+	b.state = to
+	b.generation++
+
+	switch to {
+	case Open:
+		b.openedAt = now
+	case HalfOpen:
+		b.admitted, b.succeeded = 0, 0
+	case Closed:
+		b.consecutive = 0
+		b.resetWindow()
+	}
+}
+
+// update moves an open breaker to half-open once the cool-down has passed
+func (b *Breaker) update(now datetime.ClockTime) {
+	if b.state == Open && now.Sub(b.openedAt) >= b.config.CoolDown {
+		b.setState(HalfOpen, b.openedAt.Add(b.config.CoolDown))
+	}
+}
+
+// record counts outcome of a call and changes state when a threshold is reached
+func (b *Breaker) record(success bool, now datetime.ClockTime) {
+	switch b.state {
+	case HalfOpen:
+		if !success {
+			b.setState(Open, now)
+		} else if b.succeeded++; b.succeeded >= b.config.HalfOpenRequests {
+			b.setState(Closed, now)
+		}
+		return
+	case Open:
+		return
+	case Closed:
+	}
+
+	bk := b.currentBucket(now)
+	if success {
+		bk.successes++
+		b.consecutive = 0
+		return
+	}
+
+	bk.failures++
+	b.consecutive++
+
+	if b.tripped(now) {
+		b.setState(Open, now)
+	}
+}
+
+// tripped reports whether a threshold is reached
+func (b *Breaker) tripped(now datetime.ClockTime) bool {
+	if n := b.config.ConsecutiveFailures; n > 0 && b.consecutive >= n {
+		return true
+	}
+
+	if b.config.FailureRatio <= 0 {
+		return false
+	}
+
+	successes, failures := b.window(now)
+	total := successes + failures
+
+	return total > 0 && total >= b.config.MinRequests &&
+		float64(failures) >= b.config.FailureRatio*float64(total)
+}
+
+// index returns number of the bucket period containing now
+func (b *Breaker) index(now datetime.ClockTime) int64 {
+	size := int64(b.config.BucketSize)
+	i := int64(now) / size
+	if int64(now)%size < 0 {
+		i--
+	}
+	return i
+}
+
+// currentBucket returns the bucket of now, clearing it if it held an older period
+func (b *Breaker) currentBucket(now datetime.ClockTime) *bucket {
+	i := b.index(now)
+	n := int64(len(b.buckets))
+
+	bk := &b.buckets[((i%n)+n)%n]
+	if bk.index != i {
+		*bk = bucket{index: i}
+	}
+
+	return bk
+}
+
+// window returns outcomes in buckets of the rolling window ending at now
+func (b *Breaker) window(now datetime.ClockTime) (successes, failures int) {
+	i := b.index(now)
+	n := int64(len(b.buckets))
+
+	for _, bk := range b.buckets {
+		if bk.index <= i && bk.index > i-n {
+			successes += bk.successes
+			failures += bk.failures
+		}
+	}
+
+	return successes, failures
+}
+
+// resetWindow clears all buckets
+func (b *Breaker) resetWindow() {
+	for i := range b.buckets {
+		// An index no period of the window can have
+		b.buckets[i] = bucket{index: math.MinInt64}
+	}
+}
@@ -0,0 +1,265 @@
+package circuitbreaker_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/ram-nad/go-utils/datetime"
+	"github.com/ram-nad/go-utils/datetime/circuitbreaker"
+)
+
+const start = datetime.ClockTime(1_000_000_000)
+
+var errCall = errors.New("call failed")
+
+// newBreaker creates a breaker on a manual clock, recording state changes
+func newBreaker(
+	config circuitbreaker.Config,
+) (*circuitbreaker.Breaker, *datetime.ManualClock, *[]string) {
+	clock := datetime.NewManualClock(start)
+	changes := &[]string{}
+
+	config.Clock = clock
+	config.OnStateChange = func(from, to circuitbreaker.State, at datetime.ClockTime) {
+		*changes = append(*changes, fmt.Sprintf("%v->%v@%v", from, to, at.Sub(start)))
+	}
+
+	return circuitbreaker.New(config), clock, changes
+}
+
+// call makes a call with the given outcome
+func call(b *circuitbreaker.Breaker, success bool) error {
+	return b.Do(func() error {
+		if success {
+			return nil
+		}
+		return errCall
+	})
+}
+
+func TestConsecutiveFailures(t *testing.T) {
+	b, clock, changes := newBreaker(circuitbreaker.Config{
+		BucketSize:          datetime.Seconds(1),
+		Buckets:             10,
+		ConsecutiveFailures: 3,
+		CoolDown:            datetime.Seconds(30),
+	})
+
+	for _, success := range []bool{false, false, true, false, false} {
+		_ = call(b, success)
+		clock.Advance(datetime.Seconds(1))
+	}
+
+	if b.State() != circuitbreaker.Closed {
+		t.Fatalf("State() = %v after 2 failures in a row", b.State())
+	}
+
+	if err := call(b, false); !errors.Is(err, errCall) {
+		t.Fatalf("call() = %v, want %v", err, errCall)
+	}
+
+	if b.State() != circuitbreaker.Open {
+		t.Fatalf("State() = %v after 3 failures in a row", b.State())
+	}
+
+	if err := call(b, true); !errors.Is(err, circuitbreaker.ErrOpen) {
+		t.Errorf("call() = %v, want %v", err, circuitbreaker.ErrOpen)
+	}
+
+	if fmt.Sprint(*changes) != "[closed->open@5s]" {
+		t.Errorf("state changes %v", *changes)
+	}
+}
+
+func TestFailureRatio(t *testing.T) {
+	b, clock, _ := newBreaker(circuitbreaker.Config{
+		BucketSize:   datetime.Seconds(1),
+		Buckets:      10,
+		FailureRatio: 0.5,
+		MinRequests:  10,
+		CoolDown:     datetime.Seconds(30),
+	})
+
+	// Too few requests for the ratio
+	for i := range 9 {
+		_ = call(b, i%2 == 0)
+	}
+
+	if b.State() != circuitbreaker.Closed {
+		t.Fatalf("State() = %v with 4 of 9 calls failed", b.State())
+	}
+
+	for _, success := range []bool{true, false, false} {
+		_ = call(b, success)
+	}
+	clock.Advance(datetime.Seconds(5))
+
+	expected := circuitbreaker.Counts{Requests: 12, Failures: 6, ConsecutiveFailures: 2}
+	if counts := b.Counts(); counts != expected {
+		t.Fatalf("Counts() = %+v", counts)
+	}
+
+	if b.State() != circuitbreaker.Open {
+		t.Fatalf("State() = %v with 6 of 12 calls failed", b.State())
+	}
+}
+
+func TestFailureRatioRollingWindow(t *testing.T) {
+	b, clock, _ := newBreaker(circuitbreaker.Config{
+		BucketSize:   datetime.Seconds(1),
+		Buckets:      10,
+		FailureRatio: 0.5,
+		MinRequests:  4,
+		CoolDown:     datetime.Seconds(30),
+	})
+
+	for range 3 {
+		_ = call(b, false)
+	}
+
+	// Failures leave the window after 10 seconds
+	clock.Advance(datetime.Seconds(10))
+	if counts := b.Counts(); counts.Requests != 0 {
+		t.Fatalf("Counts() = %+v after the window passed", counts)
+	}
+
+	for _, success := range []bool{true, true, true, false} {
+		_ = call(b, success)
+	}
+
+	if b.State() != circuitbreaker.Closed {
+		t.Fatalf("State() = %v with 1 of 4 calls failed in the window", b.State())
+	}
+
+	// Calls in the previous bucket are still in the window
+	clock.Advance(datetime.Seconds(9))
+	_ = call(b, false)
+	_ = call(b, false)
+	_ = call(b, false)
+
+	if b.State() != circuitbreaker.Open {
+		t.Errorf("State() = %v with 4 of 7 calls failed in the window", b.State())
+	}
+}
+
+func TestHalfOpen(t *testing.T) {
+	b, clock, changes := newBreaker(circuitbreaker.Config{
+		BucketSize:          datetime.Seconds(1),
+		Buckets:             10,
+		ConsecutiveFailures: 1,
+		CoolDown:            datetime.Seconds(30),
+		HalfOpenRequests:    2,
+	})
+
+	_ = call(b, false)
+
+	clock.Advance(datetime.Seconds(29))
+	if _, err := b.Allow(); !errors.Is(err, circuitbreaker.ErrOpen) {
+		t.Fatalf("Allow() = %v during cool-down", err)
+	}
+
+	clock.Advance(datetime.Seconds(5))
+	if b.State() != circuitbreaker.HalfOpen {
+		t.Fatalf("State() = %v after cool-down", b.State())
+	}
+
+	done1, err1 := b.Allow()
+	done2, err2 := b.Allow()
+	if _, err := b.Allow(); err1 != nil || err2 != nil ||
+		!errors.Is(err, circuitbreaker.ErrTooManyRequests) {
+		t.Fatalf("Allow() = %v, %v, %v, want 2 trial calls", err1, err2, err)
+	}
+
+	// A failed trial opens the breaker again
+	done1(true)
+	done2(false)
+	if b.State() != circuitbreaker.Open {
+		t.Fatalf("State() = %v after failed trial", b.State())
+	}
+
+	clock.Advance(datetime.Seconds(30))
+	_ = call(b, true)
+	_ = call(b, true)
+
+	if b.State() != circuitbreaker.Closed {
+		t.Fatalf("State() = %v after successful trials", b.State())
+	}
+
+	expected := "[closed->open@0s open->half-open@30s half-open->open@34s " +
+		"open->half-open@1m4s half-open->closed@1m4s]"
+	if fmt.Sprint(*changes) != expected {
+		t.Errorf("state changes %v, want %v", *changes, expected)
+	}
+}
+
+func TestStaleOutcomesIgnored(t *testing.T) {
+	b, clock, _ := newBreaker(circuitbreaker.Config{
+		BucketSize:          datetime.Seconds(1),
+		Buckets:             10,
+		ConsecutiveFailures: 2,
+		CoolDown:            datetime.Seconds(30),
+	})
+
+	slow, _ := b.Allow()
+	_ = call(b, false)
+	_ = call(b, false)
+
+	clock.Advance(datetime.Seconds(30))
+	trial, _ := b.Allow()
+
+	// Outcome of a call allowed before the breaker opened doesn't end the trial
+	slow(false)
+	if b.State() != circuitbreaker.HalfOpen {
+		t.Fatalf("State() = %v after outcome of earlier call", b.State())
+	}
+
+	trial(true)
+	if b.State() != circuitbreaker.Closed {
+		t.Fatalf("State() = %v after successful trial", b.State())
+	}
+
+	if counts := b.Counts(); counts != (circuitbreaker.Counts{}) {
+		t.Errorf("Counts() = %+v after closing", counts)
+	}
+}
+
+func TestNewPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("New() with zero BucketSize didn't panic")
+		}
+	}()
+
+	circuitbreaker.New(circuitbreaker.Config{Buckets: 1})
+}
+
+func ExampleBreaker() {
+	clock := datetime.NewManualClock(0)
+	b := circuitbreaker.New(circuitbreaker.Config{
+		BucketSize:          datetime.Seconds(1),
+		Buckets:             10,
+		ConsecutiveFailures: 2,
+		CoolDown:            datetime.Seconds(30),
+		Clock:               clock,
+		OnStateChange: func(from, to circuitbreaker.State, _ datetime.ClockTime) {
+			_, _ = fmt.Println(from, "->", to)
+		},
+	})
+
+	failing := func() error { return errors.New("unavailable") }
+	for range 3 {
+		_, _ = fmt.Println(b.Do(failing))
+	}
+
+	clock.Advance(datetime.Seconds(30))
+	_, _ = fmt.Println(b.Do(func() error { return nil }))
+	// Output:
+	// unavailable
+	// closed -> open
+	// unavailable
+	// circuitbreaker: breaker is open
+	// open -> half-open
+	// half-open -> closed
+	// <nil>
+}
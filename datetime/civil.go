@@ -0,0 +1,467 @@
+package datetime
+
+/*
+Civil date and time of day, without a time zone.
+
+A birthday or the opening hours of a store are not instants. Storing them as Time at
+midnight UTC invites off-by-one errors as soon as the value passes through a time zone.
+LocalDate and TimeOfDay hold only the calendar fields, At combines them into a Time.
+
+Both types are comparable with == when valid, format as ISO 8601 ("2006-01-02" and
+"15:04:05.999999999") and implement encoding.TextMarshaler, encoding.TextUnmarshaler,
+sql.Scanner and driver.Valuer. Values are stored in databases as ISO 8601 strings,
+scanning also accepts time.Time, using its fields in its own location.
+
+The zero LocalDate stands for no date, it is encoded as empty text and as NULL. The
+zero TimeOfDay is midnight. Values which the parsers can't read back, like February 30
+or year 10000, fail to encode with ErrOutOfRange.
+*/
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"time"
+)
+
+// LocalDate is a date in the proleptic Gregorian calendar without a time zone
+type LocalDate struct {
+	Year  int
+	Month Month
+	Day   int
+}
+
+// TimeOfDay is a time within a day without a date and a time zone
+type TimeOfDay struct {
+	Hour       int
+	Minute     int
+	Second     int
+	Nanosecond int
+}
+
+const (
+	localDateLen    = len("2006-01-02")
+	minTimeOfDayLen = len("15:04")
+	timeOfDayLen    = len("15:04:05")
+	dayDuration     = Duration(secondsPerDay * int64(time.Second))
+)
+
+// NewLocalDate returns the date, values outside their usual ranges are normalized
+func NewLocalDate(year, month, day int) LocalDate {
+	total := int64(year)*monthsPerYear + int64(month-1)
+	y := floorDiv(total, monthsPerYear)
+	m := int(total-y*monthsPerYear) + 1
+
+	return localDateFromDays(daysFromCivil(y, m, 1) + int64(day-1))
+}
+
+// LocalDateOf returns the date of t
+func LocalDateOf(t Time) LocalDate {
+	return LocalDate{Year: t.Year(), Month: t.Month(), Day: t.Day()}
+}
+
+func localDateFromDays(days int64) LocalDate {
+	year, month, day := civilFromDays(days)
+	return LocalDate{Year: int(year), Month: Month(month), Day: day}
+}
+
+/*
+ParseLocalDate parses a date in "2006-01-02" format.
+
+Errors are either ErrInvalidSyntax or ErrOutOfRange.
+*/
+//nolint:mnd // field offsets of the date
+func ParseLocalDate(s string) (LocalDate, error) {
+	if len(s) != localDateLen || s[4] != '-' || s[7] != '-' {
+		return LocalDate{}, ErrInvalidSyntax
+	}
+
+	year, ok1 := parseDigits(s[0:4])
+	month, ok2 := parseDigits(s[5:7])
+	day, ok3 := parseDigits(s[8:10])
+	if !ok1 || !ok2 || !ok3 {
+		return LocalDate{}, ErrInvalidSyntax
+	}
+
+	d := LocalDate{Year: year, Month: Month(month), Day: day}
+	if !d.IsValid() {
+		return LocalDate{}, ErrOutOfRange
+	}
+
+	return d, nil
+}
+
+// IsValid reports whether the date exists in the calendar
+func (d LocalDate) IsValid() bool {
+	return d.Month >= time.January && d.Month <= time.December && d.Day >= 1 &&
+		d.Day <= DaysInMonth(d.Year, d.Month)
+}
+
+// IsZero reports whether the date is the zero value
+func (d LocalDate) IsZero() bool {
+	return d == LocalDate{}
+}
+
+// days returns number of days since 1970-01-01
+func (d LocalDate) days() int64 {
+	return daysFromCivil(int64(d.Year), int(d.Month), d.Day)
+}
+
+// String returns the date in "2006-01-02" format
+func (d LocalDate) String() string {
+	return string(d.AppendFormat(make([]byte, 0, localDateLen)))
+}
+
+// AppendFormat appends the date in "2006-01-02" format to dst
+//
+//nolint:mnd // years with more than 4 digits
+func (d LocalDate) AppendFormat(dst []byte) []byte {
+	if d.Year < 0 || d.Year > 9999 {
+		dst = appendPadded(dst, int64(d.Year), 4, '0')
+	} else {
+		var buf [4]byte
+		put2(buf[0:], d.Year/100)
+		put2(buf[2:], d.Year%100)
+		dst = append(dst, buf[:]...)
+	}
+
+	var buf [6]byte
+	buf[0] = '-'
+	put2(buf[1:], int(d.Month))
+	buf[3] = '-'
+	put2(buf[4:], d.Day)
+
+	return append(dst, buf[:]...)
+}
+
+// Weekday returns day of the week of the date
+func (d LocalDate) Weekday() Weekday {
+	// 1970-01-01 was a Thursday
+	weekday := (d.days()%daysPerWeek + daysPerWeek + int64(time.Thursday)) % daysPerWeek
+	return Weekday(weekday)
+}
+
+// YearDay returns day of the year, in range [1, 366]
+func (d LocalDate) YearDay() int {
+	return int(d.days()-daysFromCivil(int64(d.Year), 1, 1)) + 1
+}
+
+// AddDays returns the date n days later, n may be negative
+func (d LocalDate) AddDays(n int) LocalDate {
+	return localDateFromDays(d.days() + int64(n))
+}
+
+/*
+AddMonths adds months to the date, handling missing days according to policy. Adding
+to the zero date or another invalid date fails with ErrOutOfRange. On errors the zero
+date is returned.
+*/
+func (d LocalDate) AddMonths(months int, policy OverflowPolicy) (LocalDate, error) {
+	if !d.IsValid() {
+		return LocalDate{}, fmt.Errorf(
+			"datetime: adding months to date %v: %w", d, ErrOutOfRange,
+		)
+	}
+
+	t, err := d.At(TimeOfDay{}).AddMonths(months, policy)
+	if err != nil {
+		return LocalDate{}, err
+	}
+
+	return LocalDateOf(t), nil
+}
+
+// AddYears adds years to the date, Feb 29 is handled according to policy
+func (d LocalDate) AddYears(years int, policy OverflowPolicy) (LocalDate, error) {
+	return d.AddMonths(years*monthsPerYear, policy)
+}
+
+// DaysSince returns number of days from o to d
+func (d LocalDate) DaysSince(o LocalDate) int {
+	return int(d.days() - o.days())
+}
+
+// Compare returns -1, 0 or 1 when d is before, equal to or after o
+func (d LocalDate) Compare(o LocalDate) int {
+	return compareInt64(d.days(), o.days())
+}
+
+// Before reports whether d is before o
+func (d LocalDate) Before(o LocalDate) bool {
+	return d.Compare(o) < 0
+}
+
+// After reports whether d is after o
+func (d LocalDate) After(o LocalDate) bool {
+	return d.Compare(o) > 0
+}
+
+// At returns the Time in UTC at the time of day on the date
+func (d LocalDate) At(tod TimeOfDay) Time {
+	return Unix(d.days()*secondsPerDay, 0).Add(tod.SinceMidnight())
+}
+
+// MarshalText implements encoding.TextMarshaler, the zero date is empty text
+//
+//nolint:mnd // years with more than 4 digits
+func (d LocalDate) MarshalText() ([]byte, error) {
+	if d.IsZero() {
+		return []byte{}, nil
+	}
+
+	if !d.IsValid() || d.Year < 0 || d.Year > 9999 {
+		return nil, fmt.Errorf("datetime: encoding date %v: %w", d, ErrOutOfRange)
+	}
+
+	return d.AppendFormat(make([]byte, 0, localDateLen)), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, empty text is the zero date
+func (d *LocalDate) UnmarshalText(data []byte) error {
+	if len(data) == 0 {
+		*d = LocalDate{}
+		return nil
+	}
+
+	parsed, err := ParseLocalDate(string(data))
+	if err != nil {
+		return fmt.Errorf("datetime: parsing date %q: %w", data, err)
+	}
+
+	*d = parsed
+	return nil
+}
+
+// Value implements driver.Valuer, the zero date is NULL
+func (d LocalDate) Value() (driver.Value, error) {
+	if d.IsZero() {
+		return nil, nil
+	}
+
+	text, err := d.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+
+	return string(text), nil
+}
+
+// Scan implements sql.Scanner, NULL is scanned as the zero date
+func (d *LocalDate) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*d = LocalDate{}
+		return nil
+	case time.Time:
+		*d = LocalDate{Year: v.Year(), Month: v.Month(), Day: v.Day()}
+		return nil
+	case string:
+		return d.UnmarshalText([]byte(v))
+	case []byte:
+		return d.UnmarshalText(v)
+	default:
+		return fmt.Errorf("datetime: cannot scan %T into LocalDate", src)
+	}
+}
+
+// NewTimeOfDay returns the time of day, values are normalized and wrap around midnight
+func NewTimeOfDay(hour, minute, second, nsec int) TimeOfDay {
+	d := Hours(int64(hour)) + Minutes(int64(minute)) + Seconds(int64(second)) +
+		Nanoseconds(int64(nsec))
+	return TimeOfDayAt(d)
+}
+
+// TimeOfDayOf returns the time of day of t
+func TimeOfDayOf(t Time) TimeOfDay {
+	return TimeOfDay{
+		Hour:       t.Hour(),
+		Minute:     t.Minute(),
+		Second:     t.Second(),
+		Nanosecond: t.Nanosecond(),
+	}
+}
+
+// TimeOfDayAt returns the time of day d after midnight, wrapping around midnight
+func TimeOfDayAt(d Duration) TimeOfDay {
+	d %= dayDuration
+	if d < 0 {
+		d += dayDuration
+	}
+
+	return TimeOfDay{
+		Hour:       int(d / Hours(1)),
+		Minute:     int(d % Hours(1) / Minutes(1)),
+		Second:     int(d % Minutes(1) / Seconds(1)),
+		Nanosecond: int(d % Seconds(1)),
+	}
+}
+
+/*
+ParseTimeOfDay parses a time of day in "15:04", "15:04:05" or "15:04:05.999999999"
+format, with 1 to 9 fractional digits.
+
+Errors are either ErrInvalidSyntax or ErrOutOfRange.
+*/
+//nolint:mnd // field offsets of the time of day
+func ParseTimeOfDay(s string) (TimeOfDay, error) {
+	if len(s) < minTimeOfDayLen || s[2] != ':' ||
+		len(s) > minTimeOfDayLen && (len(s) < timeOfDayLen || s[5] != ':') {
+		return TimeOfDay{}, ErrInvalidSyntax
+	}
+
+	hour, ok1 := parseDigits(s[0:2])
+	minute, ok2 := parseDigits(s[3:5])
+	second, ok3 := 0, true
+	if len(s) > minTimeOfDayLen {
+		second, ok3 = parseDigits(s[6:8])
+	}
+
+	if !ok1 || !ok2 || !ok3 {
+		return TimeOfDay{}, ErrInvalidSyntax
+	}
+
+	rest := s[min(len(s), timeOfDayLen):]
+	nsec, n, err := parseFraction(rest)
+	if err != nil || n != len(rest) {
+		return TimeOfDay{}, ErrInvalidSyntax
+	}
+
+	tod := TimeOfDay{Hour: hour, Minute: minute, Second: second, Nanosecond: nsec}
+	if !tod.IsValid() {
+		return TimeOfDay{}, ErrOutOfRange
+	}
+
+	return tod, nil
+}
+
+// IsValid reports whether all fields are in their usual ranges
+//
+//nolint:mnd // ranges of time fields
+func (t TimeOfDay) IsValid() bool {
+	return t.Hour >= 0 && t.Hour < 24 && t.Minute >= 0 && t.Minute < 60 &&
+		t.Second >= 0 && t.Second < 60 && t.Nanosecond >= 0 &&
+		t.Nanosecond < int(Seconds(1))
+}
+
+// SinceMidnight returns duration from midnight to the time of day
+func (t TimeOfDay) SinceMidnight() Duration {
+	return Hours(int64(t.Hour)) + Minutes(int64(t.Minute)) + Seconds(int64(t.Second)) +
+		Nanoseconds(int64(t.Nanosecond))
+}
+
+// String returns the time of day in "15:04:05.999999999" format
+func (t TimeOfDay) String() string {
+	var buf [timeOfDayLen + maxFracDigits + 1]byte
+	return string(t.AppendFormat(buf[:0]))
+}
+
+// AppendFormat appends the time of day in "15:04:05.999999999" format to dst
+//
+//nolint:mnd // field offsets of the time of day
+func (t TimeOfDay) AppendFormat(dst []byte) []byte {
+	var buf [timeOfDayLen + maxFracDigits + 1]byte
+
+	put2(buf[0:], t.Hour)
+	buf[2] = ':'
+	put2(buf[3:], t.Minute)
+	buf[5] = ':'
+	put2(buf[6:], t.Second)
+	n := timeOfDayLen +
+		putFraction(buf[timeOfDayLen:], t.Nanosecond, maxFracDigits, true)
+
+	return append(dst, buf[:n]...)
+}
+
+// Add returns the time of day d later, wrapping around midnight
+func (t TimeOfDay) Add(d Duration) TimeOfDay {
+	return TimeOfDayAt(t.SinceMidnight() + d%dayDuration)
+}
+
+// Sub returns the duration t-o, both are on the same day
+func (t TimeOfDay) Sub(o TimeOfDay) Duration {
+	return t.SinceMidnight() - o.SinceMidnight()
+}
+
+// Compare returns -1, 0 or 1 when t is before, equal to or after o
+func (t TimeOfDay) Compare(o TimeOfDay) int {
+	return compareInt64(int64(t.SinceMidnight()), int64(o.SinceMidnight()))
+}
+
+// Before reports whether t is before o
+func (t TimeOfDay) Before(o TimeOfDay) bool {
+	return t.Compare(o) < 0
+}
+
+// After reports whether t is after o
+func (t TimeOfDay) After(o TimeOfDay) bool {
+	return t.Compare(o) > 0
+}
+
+// On returns the Time in UTC at the time of day on the date
+func (t TimeOfDay) On(d LocalDate) Time {
+	return d.At(t)
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (t TimeOfDay) MarshalText() ([]byte, error) {
+	if !t.IsValid() {
+		err := fmt.Errorf("datetime: encoding time of day %v: %w", t, ErrOutOfRange)
+		return nil, err
+	}
+
+	return t.AppendFormat(nil), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (t *TimeOfDay) UnmarshalText(data []byte) error {
+	parsed, err := ParseTimeOfDay(string(data))
+	if err != nil {
+		return fmt.Errorf("datetime: parsing time of day %q: %w", data, err)
+	}
+
+	*t = parsed
+	return nil
+}
+
+// Value implements driver.Valuer
+func (t TimeOfDay) Value() (driver.Value, error) {
+	text, err := t.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+
+	return string(text), nil
+}
+
+// Scan implements sql.Scanner, NULL is scanned as midnight
+func (t *TimeOfDay) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*t = TimeOfDay{}
+		return nil
+	case time.Time:
+		*t = TimeOfDay{
+			Hour:       v.Hour(),
+			Minute:     v.Minute(),
+			Second:     v.Second(),
+			Nanosecond: v.Nanosecond(),
+		}
+		return nil
+	case string:
+		return t.UnmarshalText([]byte(v))
+	case []byte:
+		return t.UnmarshalText(v)
+	default:
+		return fmt.Errorf("datetime: cannot scan %T into TimeOfDay", src)
+	}
+}
+
+func compareInt64(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
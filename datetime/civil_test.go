@@ -0,0 +1,388 @@
+package datetime_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ram-nad/go-utils/datetime"
+)
+
+func TestNewLocalDate(t *testing.T) {
+	tests := []struct {
+		year, month, day int
+		expected         datetime.LocalDate
+	}{
+		{2021, 8, 9, datetime.LocalDate{Year: 2021, Month: time.August, Day: 9}},
+		{2021, 2, 29, datetime.LocalDate{Year: 2021, Month: time.March, Day: 1}},
+		{2021, 13, 1, datetime.LocalDate{Year: 2022, Month: time.January, Day: 1}},
+		{2021, 0, 0, datetime.LocalDate{Year: 2020, Month: time.November, Day: 30}},
+		{2021, 1, 366, datetime.LocalDate{Year: 2022, Month: time.January, Day: 1}},
+		{-1, 12, 31, datetime.LocalDate{Year: -1, Month: time.December, Day: 31}},
+	}
+
+	for _, tc := range tests {
+		got := datetime.NewLocalDate(tc.year, tc.month, tc.day)
+		if got != tc.expected {
+			t.Errorf(
+				"NewLocalDate(%d, %d, %d) = %v, want %v",
+				tc.year,
+				tc.month,
+				tc.day,
+				got,
+				tc.expected,
+			)
+		}
+	}
+}
+
+func TestLocalDateMatchesTime(t *testing.T) {
+	start := datetime.Date(1890, 1, 1, 0, 0, 0, 0)
+
+	for day := 0; day < 366*250; day += 7 {
+		dt := start.AddDate(0, 0, day)
+		d := datetime.LocalDateOf(dt)
+
+		if d.Weekday() != dt.Weekday() || d.YearDay() != dt.YearDay() {
+			t.Fatalf(
+				"%v: Weekday, YearDay = %v, %d, want %v, %d",
+				d,
+				d.Weekday(),
+				d.YearDay(),
+				dt.Weekday(),
+				dt.YearDay(),
+			)
+		}
+
+		if got := d.At(datetime.TimeOfDay{}); !got.Equal(dt) {
+			t.Fatalf("%v.At(midnight) = %v, want %v", d, got, dt)
+		}
+
+		if got := d.String(); got != dt.Format(datetime.DateOnly) {
+			t.Fatalf("String() = %q, want %q", got, dt.Format(datetime.DateOnly))
+		}
+	}
+}
+
+func TestLocalDateArithmetic(t *testing.T) {
+	d := datetime.LocalDate{Year: 2024, Month: time.January, Day: 31}
+
+	march := datetime.LocalDate{Year: 2024, Month: time.March, Day: 1}
+	if got := d.AddDays(30); got != march {
+		t.Errorf("AddDays(30) = %v, want %v", got, march)
+	}
+
+	eve := datetime.LocalDate{Year: 2023, Month: time.December, Day: 31}
+	if got := d.AddDays(-31); got != eve {
+		t.Errorf("AddDays(-31) = %v, want %v", got, eve)
+	}
+
+	got, err := d.AddMonths(1, datetime.ClampToMonthEnd)
+	if err != nil || got != (datetime.LocalDate{Year: 2024, Month: 2, Day: 29}) {
+		t.Errorf("AddMonths(1) = %v, %v", got, err)
+	}
+
+	got, err = d.AddMonths(1, datetime.ErrorOnOverflow)
+	if !errors.Is(err, datetime.ErrMonthOverflow) || !got.IsZero() {
+		t.Errorf(
+			"AddMonths(1, ErrorOnOverflow) = %v, %v, want zero date and "+
+				"ErrMonthOverflow",
+			got, err,
+		)
+	}
+
+	// The zero date is no date, not 0000-00-00
+	got, err = datetime.LocalDate{}.AddMonths(1, datetime.ClampToMonthEnd)
+	if !errors.Is(err, datetime.ErrOutOfRange) || !got.IsZero() {
+		t.Errorf(
+			"AddMonths(1) of zero date = %v, %v, want zero date and ErrOutOfRange",
+			got, err,
+		)
+	}
+
+	leap := datetime.LocalDate{Year: 2024, Month: time.February, Day: 29}
+	got, err = leap.AddYears(1, datetime.ClampToMonthEnd)
+	if err != nil || got != (datetime.LocalDate{Year: 2025, Month: 2, Day: 28}) {
+		t.Errorf("AddYears(1) = %v, %v", got, err)
+	}
+
+	if n := leap.DaysSince(d); n != 29 {
+		t.Errorf("DaysSince() = %d, want 29", n)
+	}
+
+	if !d.Before(leap) || d.After(leap) || leap.Compare(d) != 1 || d.Compare(d) != 0 {
+		t.Errorf("Comparison of %v and %v is wrong", d, leap)
+	}
+}
+
+func TestParseLocalDate(t *testing.T) {
+	got, err := datetime.ParseLocalDate("2024-02-29")
+	if err != nil || got != (datetime.LocalDate{Year: 2024, Month: 2, Day: 29}) {
+		t.Errorf("ParseLocalDate(2024-02-29) = %v, %v", got, err)
+	}
+
+	tests := []struct {
+		s   string
+		err error
+	}{
+		{"", datetime.ErrInvalidSyntax},
+		{"2024-2-29", datetime.ErrInvalidSyntax},
+		{"2024/02/29", datetime.ErrInvalidSyntax},
+		{"2024-02-29T00:00:00Z", datetime.ErrInvalidSyntax},
+		{"2023-02-29", datetime.ErrOutOfRange},
+		{"2023-00-10", datetime.ErrOutOfRange},
+		{"2023-04-31", datetime.ErrOutOfRange},
+	}
+
+	for _, tc := range tests {
+		if _, err := datetime.ParseLocalDate(tc.s); !errors.Is(err, tc.err) {
+			t.Errorf("ParseLocalDate(%q) = %v, want %v", tc.s, err, tc.err)
+		}
+	}
+}
+
+func TestTimeOfDay(t *testing.T) {
+	tod := datetime.NewTimeOfDay(23, 30, 0, 0)
+
+	tests := []struct {
+		d        datetime.Duration
+		expected datetime.TimeOfDay
+	}{
+		{datetime.Minutes(45), datetime.TimeOfDay{Hour: 0, Minute: 15}},
+		{datetime.Hours(-24), tod},
+		{
+			datetime.Hours(48) + 1,
+			datetime.TimeOfDay{Hour: 23, Minute: 30, Nanosecond: 1},
+		},
+		{-datetime.Minutes(90), datetime.TimeOfDay{Hour: 22}},
+	}
+
+	for _, tc := range tests {
+		if got := tod.Add(tc.d); got != tc.expected {
+			t.Errorf("Add(%v) = %v, want %v", tc.d, got, tc.expected)
+		}
+	}
+
+	wrapped := datetime.TimeOfDay{Minute: 59}
+	if got := datetime.NewTimeOfDay(25, -1, 0, 0); got != wrapped {
+		t.Errorf("NewTimeOfDay(25, -1, 0, 0) = %v, want %v", got, wrapped)
+	}
+
+	opening := datetime.TimeOfDay{Hour: 9}
+	if d := tod.Sub(opening); d != datetime.Minutes(870) {
+		t.Errorf("Sub() = %v, want 14h30m", d)
+	}
+
+	if !opening.Before(tod) || opening.After(tod) || tod.Compare(tod) != 0 {
+		t.Errorf("Comparison of %v and %v is wrong", opening, tod)
+	}
+
+	dt := datetime.Date(2021, 8, 9, 16, 7, 3, 42)
+	if got := datetime.TimeOfDayOf(dt).On(datetime.LocalDateOf(dt)); !got.Equal(dt) {
+		t.Errorf("TimeOfDayOf(t).On(LocalDateOf(t)) = %v, want %v", got, dt)
+	}
+}
+
+func TestParseTimeOfDay(t *testing.T) {
+	valid := []struct {
+		s        string
+		expected datetime.TimeOfDay
+	}{
+		{"09:30", datetime.TimeOfDay{Hour: 9, Minute: 30}},
+		{"23:59:59", datetime.TimeOfDay{Hour: 23, Minute: 59, Second: 59}},
+		{"00:00:00.5", datetime.TimeOfDay{Nanosecond: 500_000_000}},
+		{"12:00:01.000000001", datetime.TimeOfDay{Hour: 12, Second: 1, Nanosecond: 1}},
+	}
+
+	for _, tc := range valid {
+		got, err := datetime.ParseTimeOfDay(tc.s)
+		if err != nil || got != tc.expected {
+			t.Errorf(
+				"ParseTimeOfDay(%q) = %v, %v, want %v",
+				tc.s,
+				got,
+				err,
+				tc.expected,
+			)
+		}
+
+		if tc.s != "09:30" && got.String() != tc.s {
+			t.Errorf("ParseTimeOfDay(%q).String() = %q", tc.s, got.String())
+		}
+	}
+
+	invalid := []struct {
+		s   string
+		err error
+	}{
+		{"", datetime.ErrInvalidSyntax},
+		{"9:30", datetime.ErrInvalidSyntax},
+		{"09:30:0", datetime.ErrInvalidSyntax},
+		{"09-30-00", datetime.ErrInvalidSyntax},
+		{"09:30:00.", datetime.ErrInvalidSyntax},
+		{"09:30:00Z", datetime.ErrInvalidSyntax},
+		{"24:00:00", datetime.ErrOutOfRange},
+		{"23:60", datetime.ErrOutOfRange},
+	}
+
+	for _, tc := range invalid {
+		if _, err := datetime.ParseTimeOfDay(tc.s); !errors.Is(err, tc.err) {
+			t.Errorf("ParseTimeOfDay(%q) = %v, want %v", tc.s, err, tc.err)
+		}
+	}
+}
+
+func TestCivilJSON(t *testing.T) {
+	type store struct {
+		Opened datetime.LocalDate `json:"opened"`
+		Opens  datetime.TimeOfDay `json:"opens"`
+	}
+
+	s := store{
+		Opened: datetime.LocalDate{Year: 1999, Month: time.December, Day: 31},
+		Opens:  datetime.TimeOfDay{Hour: 8, Minute: 30},
+	}
+
+	data, err := json.Marshal(s)
+	expected := `{"opened":"1999-12-31","opens":"08:30:00"}`
+	if err != nil || string(data) != expected {
+		t.Fatalf("json.Marshal() = %s, %v, want %s", data, err, expected)
+	}
+
+	var decoded store
+	if err := json.Unmarshal(data, &decoded); err != nil || decoded != s {
+		t.Errorf("json.Unmarshal() = %+v, %v, want %+v", decoded, err, s)
+	}
+
+	if err := json.Unmarshal([]byte(`{"opened":"1999-13-01"}`), &decoded); err == nil {
+		t.Errorf("Expected error for invalid date")
+	}
+}
+
+func TestCivilSQL(t *testing.T) {
+	d := datetime.LocalDate{Year: 2021, Month: time.August, Day: 9}
+	tod := datetime.TimeOfDay{Hour: 16, Minute: 7, Second: 3}
+
+	if v, err := d.Value(); err != nil || v != "2021-08-09" {
+		t.Errorf("LocalDate.Value() = %v, %v", v, err)
+	}
+
+	if v, err := tod.Value(); err != nil || v != "16:07:03" {
+		t.Errorf("TimeOfDay.Value() = %v, %v", v, err)
+	}
+
+	// Drivers return DATE and TIME columns as time.Time in various locations
+	ist := time.FixedZone("IST", 5*60*60+30*60)
+	sources := []any{
+		"2021-08-09",
+		[]byte("2021-08-09"),
+		time.Date(2021, 8, 9, 0, 0, 0, 0, ist),
+	}
+
+	for _, src := range sources {
+		var got datetime.LocalDate
+		if err := got.Scan(src); err != nil || got != d {
+			t.Errorf("LocalDate.Scan(%#v) = %v, %v, want %v", src, got, err, d)
+		}
+	}
+
+	for _, src := range []any{"16:07:03", time.Date(0, 1, 1, 16, 7, 3, 0, ist)} {
+		var got datetime.TimeOfDay
+		if err := got.Scan(src); err != nil || got != tod {
+			t.Errorf("TimeOfDay.Scan(%#v) = %v, %v, want %v", src, got, err, tod)
+		}
+	}
+
+	var got datetime.LocalDate
+	if err := got.Scan(nil); err != nil || !got.IsZero() {
+		t.Errorf("LocalDate.Scan(nil) = %v, %v", got, err)
+	}
+
+	if err := got.Scan(42); err == nil {
+		t.Errorf("Expected error scanning int")
+	}
+}
+
+func TestCivilZeroRoundTrip(t *testing.T) {
+	type store struct {
+		Opened datetime.LocalDate `json:"opened"`
+		Opens  datetime.TimeOfDay `json:"opens"`
+	}
+
+	data, err := json.Marshal(store{})
+	expected := `{"opened":"","opens":"00:00:00"}`
+	if err != nil || string(data) != expected {
+		t.Fatalf("json.Marshal() = %s, %v, want %s", data, err, expected)
+	}
+
+	decoded := store{
+		Opened: datetime.LocalDate{Year: 1999, Month: time.December, Day: 31},
+		Opens:  datetime.TimeOfDay{Hour: 8},
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil || decoded != (store{}) {
+		t.Errorf("json.Unmarshal() = %+v, %v, want zero values", decoded, err)
+	}
+
+	// NULL and the zero date map to each other
+	var d datetime.LocalDate
+	v, err := d.Value()
+	if err != nil || v != nil {
+		t.Errorf("LocalDate.Value() = %v, %v, want nil", v, err)
+	}
+
+	d = datetime.LocalDate{Year: 2021, Month: time.August, Day: 9}
+	if err := d.Scan(v); err != nil || !d.IsZero() {
+		t.Errorf("LocalDate.Scan(%v) = %v, %v, want zero date", v, d, err)
+	}
+
+	// Midnight is stored as a time, not NULL
+	var tod datetime.TimeOfDay
+	v, err = tod.Value()
+	if err != nil || v != "00:00:00" {
+		t.Errorf("TimeOfDay.Value() = %v, %v, want 00:00:00", v, err)
+	}
+
+	tod = datetime.TimeOfDay{Hour: 16}
+	if err := tod.Scan(v); err != nil || tod != (datetime.TimeOfDay{}) {
+		t.Errorf("TimeOfDay.Scan(%v) = %v, %v, want midnight", v, tod, err)
+	}
+}
+
+func TestCivilInvalidEncoding(t *testing.T) {
+	for _, d := range []datetime.LocalDate{
+		{Year: 2021, Month: time.February, Day: 30},
+		{Year: 10000, Month: time.January, Day: 1},
+		{Year: 2021},
+	} {
+		if _, err := d.MarshalText(); !errors.Is(err, datetime.ErrOutOfRange) {
+			t.Errorf("LocalDate.MarshalText() of %v = %v, want ErrOutOfRange", d, err)
+		}
+
+		if _, err := d.Value(); !errors.Is(err, datetime.ErrOutOfRange) {
+			t.Errorf("LocalDate.Value() of %v = %v, want ErrOutOfRange", d, err)
+		}
+	}
+
+	tod := datetime.TimeOfDay{Hour: 24}
+	if _, err := tod.MarshalText(); !errors.Is(err, datetime.ErrOutOfRange) {
+		t.Errorf("TimeOfDay.MarshalText() of %v = %v, want ErrOutOfRange", tod, err)
+	}
+
+	if _, err := tod.Value(); !errors.Is(err, datetime.ErrOutOfRange) {
+		t.Errorf("TimeOfDay.Value() of %v = %v, want ErrOutOfRange", tod, err)
+	}
+}
+
+func ExampleLocalDate() {
+	birthday := datetime.LocalDate{Year: 1990, Month: time.May, Day: 17}
+	opens := datetime.TimeOfDay{Hour: 9}
+
+	next, _ := birthday.AddYears(31, datetime.ClampToMonthEnd)
+	_, _ = fmt.Println(next, next.Weekday())
+	_, _ = fmt.Println(next.At(opens))
+	// Output:
+	// 2021-05-17 Monday
+	// Mon, 17 May 2021 09:00:00 UTC
+}
@@ -52,14 +52,30 @@ func addClockTime(t1 ClockTime, d Duration) ClockTime {
 	return r
 }
 
-func NowClock() ClockTime {
-	now := time.Now()
+// formatClock formats nanoseconds as seconds, prefix identifies the clock
+func formatClock(prefix byte, t int64) string {
+	s := t / int64(time.Second)
+	ns := t % int64(time.Second)
+	if ns < 0 {
+		ns = -ns
+	}
+	return fmt.Sprintf("%c%+d.%09d", prefix, s, ns)
+}
+
+// clockFromTime extracts the monotonic clock reading from time returned by time.Now
+func clockFromTime(now *time.Time) ClockTime {
 	//nolint:gosec // this depends on internals of time.Time to get access to monotonic clock
-	mono := *(*int64)(unsafe.Pointer(uintptr(unsafe.Pointer(&now)) + unsafe.Sizeof(uint64(0))))
+	mono := *(*int64)(unsafe.Pointer(uintptr(unsafe.Pointer(now)) + unsafe.Sizeof(uint64(0))))
 
 	return ClockTime(mono)
 }
 
+func NowClock() ClockTime {
+	now := time.Now()
+
+	return clockFromTime(&now)
+}
+
 func SinceClock(t ClockTime) Duration {
 	now := NowClock()
 
@@ -82,12 +98,7 @@ func (t ClockTime) Add(d Duration) ClockTime {
 
 // String returns Human readable string representation of monotonic clock time
 func (t ClockTime) String() string {
-	s := int64(t) / int64(time.Second)
-	ns := int64(t) % int64(time.Second)
-	if ns < 0 {
-		ns = -ns
-	}
-	return fmt.Sprintf("m%+d.%09d", s, ns)
+	return formatClock('m', int64(t))
 }
 
 // GoString returns Go's representation of the monotonic clock time
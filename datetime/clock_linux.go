@@ -0,0 +1,181 @@
+//go:build linux
+
+package datetime
+
+/*
+Additional clock sources available on Linux, read using clock_gettime(2).
+
+ClockTime is backed by Go's monotonic clock (CLOCK_MONOTONIC), which stops while
+the system is suspended. These clocks cover the remaining use cases:
+
+	BootClockTime   - CLOCK_BOOTTIME, like ClockTime but keeps counting during suspend
+	RawClockTime    - CLOCK_MONOTONIC_RAW, not subject to NTP frequency adjustments
+	ProcessCPUTime  - CLOCK_PROCESS_CPUTIME_ID, CPU time consumed by all threads of the process
+	ThreadCPUTime   - CLOCK_THREAD_CPUTIME_ID, CPU time consumed by the calling OS thread
+
+Example:
+	wallStart, cpuStart := datetime.NowClock(), datetime.NowProcessCPU()
+	.... do something ....
+	fmt.Printf("wall: %v, cpu: %v", datetime.SinceClock(wallStart), datetime.SinceProcessCPU(cpuStart))
+*/
+
+import (
+	"fmt"
+	"syscall"
+	"unsafe"
+)
+
+// Clock IDs from <linux/time.h>
+const (
+	clockProcessCPUTimeID = 2
+	clockThreadCPUTimeID  = 3
+	clockMonotonicRaw     = 4
+	clockBoottime         = 7
+)
+
+// BootClockTime is used for measuring time including the time system was suspended
+type BootClockTime int64
+
+// RawClockTime is used for measuring time using hardware based monotonic clock
+type RawClockTime int64
+
+// ProcessCPUTime is used for measuring CPU time consumed by the process
+type ProcessCPUTime int64
+
+// ThreadCPUTime is used for measuring CPU time consumed by the current OS thread
+type ThreadCPUTime int64
+
+// clockGettime reads the given clock. It panics if the kernel doesn't support the clock.
+func clockGettime(clockID int) int64 {
+	var ts syscall.Timespec
+
+	_, _, errno := syscall.Syscall(
+		syscall.SYS_CLOCK_GETTIME,
+		uintptr(clockID),
+		//nolint:gosec // pointer is only used for the duration of the syscall
+		uintptr(unsafe.Pointer(&ts)),
+		0,
+	)
+	if errno != 0 {
+		panic(fmt.Sprintf("datetime: clock_gettime(%d) failed: %v", clockID, errno))
+	}
+
+	return ts.Nano()
+}
+
+func NowBootClock() BootClockTime {
+	return BootClockTime(clockGettime(clockBoottime))
+}
+
+func SinceBootClock(t BootClockTime) Duration {
+	return NowBootClock().Sub(t)
+}
+
+func UntilBootClock(t BootClockTime) Duration {
+	return t.Sub(NowBootClock())
+}
+
+func (t BootClockTime) Sub(d BootClockTime) Duration {
+	return subClockTime(ClockTime(t), ClockTime(d))
+}
+
+func (t BootClockTime) Add(d Duration) BootClockTime {
+	return BootClockTime(addClockTime(ClockTime(t), d))
+}
+
+// String returns Human readable string representation of boot clock time
+func (t BootClockTime) String() string {
+	return formatClock('b', int64(t))
+}
+
+// GoString returns Go's representation of the boot clock time
+func (t BootClockTime) GoString() string {
+	return ClockTime(t).GoString()
+}
+
+func NowRawClock() RawClockTime {
+	return RawClockTime(clockGettime(clockMonotonicRaw))
+}
+
+func SinceRawClock(t RawClockTime) Duration {
+	return NowRawClock().Sub(t)
+}
+
+func UntilRawClock(t RawClockTime) Duration {
+	return t.Sub(NowRawClock())
+}
+
+func (t RawClockTime) Sub(d RawClockTime) Duration {
+	return subClockTime(ClockTime(t), ClockTime(d))
+}
+
+func (t RawClockTime) Add(d Duration) RawClockTime {
+	return RawClockTime(addClockTime(ClockTime(t), d))
+}
+
+// String returns Human readable string representation of raw clock time
+func (t RawClockTime) String() string {
+	return formatClock('r', int64(t))
+}
+
+// GoString returns Go's representation of the raw clock time
+func (t RawClockTime) GoString() string {
+	return ClockTime(t).GoString()
+}
+
+func NowProcessCPU() ProcessCPUTime {
+	return ProcessCPUTime(clockGettime(clockProcessCPUTimeID))
+}
+
+func SinceProcessCPU(t ProcessCPUTime) Duration {
+	return NowProcessCPU().Sub(t)
+}
+
+func (t ProcessCPUTime) Sub(d ProcessCPUTime) Duration {
+	return subClockTime(ClockTime(t), ClockTime(d))
+}
+
+func (t ProcessCPUTime) Add(d Duration) ProcessCPUTime {
+	return ProcessCPUTime(addClockTime(ClockTime(t), d))
+}
+
+// String returns Human readable string representation of process CPU time
+func (t ProcessCPUTime) String() string {
+	return formatClock('p', int64(t))
+}
+
+// GoString returns Go's representation of the process CPU time
+func (t ProcessCPUTime) GoString() string {
+	return ClockTime(t).GoString()
+}
+
+/*
+NowThreadCPU returns CPU time consumed by the OS thread running the caller.
+
+Goroutines can migrate between threads, so use runtime.LockOSThread while measuring.
+*/
+func NowThreadCPU() ThreadCPUTime {
+	return ThreadCPUTime(clockGettime(clockThreadCPUTimeID))
+}
+
+func SinceThreadCPU(t ThreadCPUTime) Duration {
+	return NowThreadCPU().Sub(t)
+}
+
+func (t ThreadCPUTime) Sub(d ThreadCPUTime) Duration {
+	return subClockTime(ClockTime(t), ClockTime(d))
+}
+
+func (t ThreadCPUTime) Add(d Duration) ThreadCPUTime {
+	return ThreadCPUTime(addClockTime(ClockTime(t), d))
+}
+
+// String returns Human readable string representation of thread CPU time
+func (t ThreadCPUTime) String() string {
+	return formatClock('t', int64(t))
+}
+
+// GoString returns Go's representation of the thread CPU time
+func (t ThreadCPUTime) GoString() string {
+	return ClockTime(t).GoString()
+}
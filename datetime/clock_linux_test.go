@@ -0,0 +1,152 @@
+//go:build linux
+
+package datetime_test
+
+import (
+	"runtime"
+	"syscall"
+	"testing"
+	"unsafe"
+
+	"github.com/ram-nad/go-utils/datetime"
+)
+
+func busyWork() int {
+	x := 0
+	for i := range 5_000_000 {
+		x ^= i * 31
+	}
+	return x
+}
+
+func TestNowBootClock(t *testing.T) {
+	clockNow := datetime.NowBootClock()
+	clockNext := datetime.NowBootClock()
+
+	if clockNext < clockNow {
+		t.Errorf("Expected clockNext to be greater than clockNow")
+	}
+
+	if d := datetime.SinceBootClock(clockNow); d < 0 {
+		t.Errorf("SinceBootClock(%v) = %v, want >= 0", clockNow, d)
+	}
+
+	if d := datetime.UntilBootClock(clockNow); d > 0 {
+		t.Errorf("UntilBootClock(%v) = %v, want <= 0", clockNow, d)
+	}
+}
+
+func TestNowRawClock(t *testing.T) {
+	clockNow := datetime.NowRawClock()
+	clockNext := datetime.NowRawClock()
+
+	if clockNext < clockNow {
+		t.Errorf("Expected clockNext to be greater than clockNow")
+	}
+
+	if d := datetime.SinceRawClock(clockNow); d < 0 {
+		t.Errorf("SinceRawClock(%v) = %v, want >= 0", clockNow, d)
+	}
+
+	if d := datetime.UntilRawClock(clockNow); d > 0 {
+		t.Errorf("UntilRawClock(%v) = %v, want <= 0", clockNow, d)
+	}
+}
+
+// monotonicNow reads CLOCK_MONOTONIC, which counts from boot like CLOCK_BOOTTIME
+func monotonicNow(t *testing.T) int64 {
+	t.Helper()
+
+	const clockMonotonic = 1
+
+	var ts syscall.Timespec
+	_, _, errno := syscall.Syscall(
+		syscall.SYS_CLOCK_GETTIME,
+		clockMonotonic,
+		uintptr(unsafe.Pointer(&ts)), //nolint:gosec // only used during the syscall
+		0,
+	)
+	if errno != 0 {
+		t.Fatalf("clock_gettime(CLOCK_MONOTONIC) failed: %v", errno)
+	}
+
+	return ts.Nano()
+}
+
+func TestBootClockAheadOfMonotonic(t *testing.T) {
+	// CLOCK_BOOTTIME includes suspended time, so it is never behind CLOCK_MONOTONIC
+	mono := monotonicNow(t)
+	boot := datetime.NowBootClock()
+
+	if int64(boot) < mono {
+		t.Errorf("Expected boot clock %v to be >= CLOCK_MONOTONIC %v", boot, mono)
+	}
+}
+
+func TestNowProcessCPU(t *testing.T) {
+	start := datetime.NowProcessCPU()
+	_ = busyWork()
+	used := datetime.SinceProcessCPU(start)
+
+	if used <= 0 {
+		t.Errorf("SinceProcessCPU(%v) = %v, want > 0", start, used)
+	}
+}
+
+func TestNowThreadCPU(t *testing.T) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	start := datetime.NowThreadCPU()
+	_ = busyWork()
+	used := datetime.SinceThreadCPU(start)
+
+	if used <= 0 {
+		t.Errorf("SinceThreadCPU(%v) = %v, want > 0", start, used)
+	}
+}
+
+func TestLinuxClockArithmetic(t *testing.T) {
+	b := datetime.BootClockTime(1000)
+	if got := b.Add(500).Sub(b); got != 500 {
+		t.Errorf("BootClockTime Add/Sub = %v, want 500ns", got)
+	}
+
+	r := datetime.RawClockTime(1000)
+	if got := r.Add(-500).Sub(r); got != -500 {
+		t.Errorf("RawClockTime Add/Sub = %v, want -500ns", got)
+	}
+
+	p := datetime.ProcessCPUTime(1000)
+	if got := p.Add(datetime.Seconds(1)).Sub(p); got != datetime.Seconds(1) {
+		t.Errorf("ProcessCPUTime Add/Sub = %v, want 1s", got)
+	}
+
+	th := datetime.ThreadCPUTime(1000)
+	ms := datetime.Milliseconds(3)
+	if got := th.Add(ms).Sub(th); got != ms {
+		t.Errorf("ThreadCPUTime Add/Sub = %v, want 3ms", got)
+	}
+}
+
+func TestLinuxClockString(t *testing.T) {
+	tests := []struct {
+		got      string
+		expected string
+	}{
+		{datetime.BootClockTime(24242424191000).String(), "b+24242.424191000"},
+		{datetime.RawClockTime(-15242444181000).String(), "r-15242.444181000"},
+		{datetime.ProcessCPUTime(1500000000).String(), "p+1.500000000"},
+		{datetime.ThreadCPUTime(1).String(), "t+0.000000001"},
+		{
+			datetime.ThreadCPUTime(-1545434181023).GoString(),
+			"{s: -1545, nsec: -434181023}",
+		},
+	}
+
+	for _, tc := range tests {
+		if tc.got != tc.expected {
+			t.Errorf("Expected %q, got %q", tc.expected, tc.got)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package datetime
+
+/*
+Clock sources for code which reads ClockTime and waits for it, so tests can control
+time instead of sleeping.
+
+SystemClock reads the monotonic clock and waits with timers of the runtime. A
+ManualClock only moves when advanced, which runs the callbacks that became due:
+
+	clock := datetime.NewManualClock(0)
+	clock.AfterFunc(datetime.Seconds(5), func() { fmt.Println("done") })
+	clock.Advance(datetime.Seconds(5)) // prints done
+*/
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+// Clock reads ClockTime and schedules calls after a Duration
+type Clock interface {
+	// Now returns the current time
+	Now() ClockTime
+	// AfterFunc calls f in its own goroutine once d has elapsed, stop cancels the call
+	// and reports whether it was cancelled before f was started
+	AfterFunc(d Duration, f func()) (stop func() bool)
+}
+
+// SystemClock is the monotonic clock of the system
+type SystemClock struct{}
+
+// ManualClock is a Clock which only moves when advanced, it is safe for concurrent use
+type ManualClock struct {
+	mu      sync.Mutex
+	now     ClockTime
+	waiters []*clockWaiter
+}
+
+// clockWaiter is a call scheduled on a ManualClock
+type clockWaiter struct {
+	at ClockTime
+	f  func()
+}
+
+// Now returns NowClock()
+func (SystemClock) Now() ClockTime {
+	return NowClock()
+}
+
+// AfterFunc is like time.AfterFunc
+func (SystemClock) AfterFunc(d Duration, f func()) func() bool {
+	return time.AfterFunc(time.Duration(d), f).Stop
+}
+
+// NewManualClock creates a clock reading now
+func NewManualClock(now ClockTime) *ManualClock {
+	return &ManualClock{now: now}
+}
+
+// Now returns the current time of the clock
+func (c *ManualClock) Now() ClockTime {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+// AfterFunc schedules f to run once the clock is advanced by d, or now if d <= 0
+func (c *ManualClock) AfterFunc(d Duration, f func()) func() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if d <= 0 {
+		go f()
+		return func() bool { return false }
+	}
+
+	w := &clockWaiter{at: c.now.Add(d), f: f}
+	c.waiters = append(c.waiters, w)
+
+	return func() bool {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+
+		i := slices.Index(c.waiters, w)
+		if i < 0 {
+			return false
+		}
+
+		c.waiters = slices.Delete(c.waiters, i, i+1)
+		return true
+	}
+}
+
+// Advance moves the clock forward by d and starts calls that became due
+func (c *ManualClock) Advance(d Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+	c.waiters = slices.DeleteFunc(c.waiters, func(w *clockWaiter) bool {
+		if w.at > c.now {
+			return false
+		}
+
+		go w.f()
+		return true
+	})
+}
+
+// Waiters returns the number of scheduled calls which aren't due yet
+func (c *ManualClock) Waiters() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.waiters)
+}
@@ -0,0 +1,58 @@
+package datetime_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ram-nad/go-utils/datetime"
+)
+
+func TestManualClock(t *testing.T) {
+	clock := datetime.NewManualClock(100)
+	fired := make(chan int, 3)
+
+	clock.AfterFunc(datetime.Seconds(2), func() { fired <- 2 })
+	stop := clock.AfterFunc(datetime.Seconds(1), func() { fired <- 1 })
+	clock.AfterFunc(datetime.Seconds(3), func() { fired <- 3 })
+
+	if !stop() || stop() {
+		t.Errorf("stop() of a pending call didn't report true once")
+	}
+
+	clock.Advance(datetime.Seconds(2))
+	if got := <-fired; got != 2 {
+		t.Errorf("fired %v, want 2", got)
+	}
+
+	if clock.Now() != datetime.ClockTime(100).Add(datetime.Seconds(2)) {
+		t.Errorf("Now() = %v after Advance", clock.Now())
+	}
+
+	if clock.Waiters() != 1 {
+		t.Errorf("Waiters() = %v, want 1", clock.Waiters())
+	}
+
+	clock.AfterFunc(0, func() { fired <- 0 })
+	if got := <-fired; got != 0 {
+		t.Errorf("fired %v, want 0", got)
+	}
+}
+
+func TestSystemClock(t *testing.T) {
+	var clock datetime.Clock = datetime.SystemClock{}
+
+	start := clock.Now()
+	done := make(chan datetime.Duration)
+	clock.AfterFunc(datetime.Milliseconds(1), func() {
+		done <- datetime.SinceClock(start)
+	})
+
+	select {
+	case elapsed := <-done:
+		if elapsed < datetime.Milliseconds(1) {
+			t.Errorf("AfterFunc() called after %v", elapsed)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("AfterFunc() not called")
+	}
+}
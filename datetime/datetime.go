@@ -31,8 +31,12 @@
 	RFC1123Z    PrintFormat = time.RFC1123Z
 	RFC3339     PrintFormat = time.RFC3339
 	RFC3339Nano PrintFormat = time.RFC3339Nano
-	DateOnly    PrintFormat = time.DateOnly
-	TimeOnly    PrintFormat = time.TimeOnly
+	// RFC3339Milli is RFC 3339 with fixed width milliseconds
+	RFC3339Milli PrintFormat = "2006-01-02T15:04:05.000Z07:00"
+	// RFC3339Micro is RFC 3339 with fixed width microseconds
+	RFC3339Micro PrintFormat = "2006-01-02T15:04:05.000000Z07:00"
+	DateOnly     PrintFormat = time.DateOnly
+	TimeOnly     PrintFormat = time.TimeOnly
 )
 
 func Date(year, month, day, hour, minute, second, nsec int) Time {
@@ -43,7 +47,11 @@ func Date(year, month, day, hour, minute, second, nsec int) Time {
 }
 
 func Now() Time {
-	now := time.Now()
+	return wallFromTime(time.Now())
+}
+
+// wallFromTime converts time returned by time.Now to Time
+func wallFromTime(now time.Time) Time {
 	// Make sure monotonic clock is stripped
 	return Time(time.Unix(now.Unix(), int64(now.Nanosecond())).UTC())
 }
@@ -173,7 +181,8 @@ func (t Time) Unix() int64 {
 	Output: 23:11:00
 */
 func (t Time) Format(format PrintFormat) string {
-	return time.Time(t).Format(string(format))
+	var buf [maxRFC3339Len]byte
+	return string(t.AppendFormat(buf[:0], format))
 }
 
 // ISOString returns the time in ISO 8601 format
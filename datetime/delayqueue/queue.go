@@ -0,0 +1,197 @@
+/*
+Package delayqueue holds items until their ClockTime deadline has passed.
+
+Items are kept in a heap ordered by deadline, items with the same deadline come out in
+the order they were put. Take blocks until the earliest item is due, waking up early
+when an item with an earlier deadline is put or the earliest item is removed:
+
+	queue := delayqueue.New[string](datetime.SystemClock{})
+	queue.Put("retry", datetime.NowClock().Add(datetime.Seconds(5)))
+
+	job, err := queue.Take(ctx) // about 5 seconds later
+
+Waiting goes through a datetime.Clock, so tests can use a datetime.ManualClock.
+*/
+package delayqueue
+
+import (
+	"container/heap"
+	"context"
+	"sync"
+
+	"github.com/ram-nad/go-utils/datetime"
+)
+
+// Item is an entry of the queue, it can be used to remove the entry before it is taken
+type Item[T any] struct {
+	Value    T
+	deadline datetime.ClockTime
+	// seq orders items with the same deadline
+	seq uint64
+	// index in the heap, -1 when the item isn't queued
+	index int
+}
+
+// DelayQueue is a queue of items available after their deadline, it is safe for
+// concurrent use
+type DelayQueue[T any] struct {
+	mu    sync.Mutex
+	clock datetime.Clock
+	items itemHeap[T]
+	seq   uint64
+	// changed is closed and replaced when the earliest item changes
+	changed chan struct{}
+}
+
+// itemHeap implements heap.Interface ordered by deadline
+type itemHeap[T any] []*Item[T]
+
+// New creates an empty queue, waiting for deadlines on clock
+func New[T any](clock datetime.Clock) *DelayQueue[T] {
+	return &DelayQueue[T]{clock: clock, changed: make(chan struct{})}
+}
+
+// Deadline returns the time the item becomes available
+func (it *Item[T]) Deadline() datetime.ClockTime {
+	return it.deadline
+}
+
+// Put adds v to the queue, it becomes available once the clock reaches deadline
+func (q *DelayQueue[T]) Put(v T, deadline datetime.ClockTime) *Item[T] {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	it := &Item[T]{Value: v, deadline: deadline, seq: q.seq}
+	q.seq++
+
+	heap.Push(&q.items, it)
+	if it.index == 0 {
+		q.notify()
+	}
+
+	return it
+}
+
+// Remove removes the item from the queue, it reports whether the item was queued
+func (q *DelayQueue[T]) Remove(it *Item[T]) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if it.index < 0 || it.index >= len(q.items) || q.items[it.index] != it {
+		return false
+	}
+
+	first := it.index == 0
+	heap.Remove(&q.items, it.index)
+	if first {
+		q.notify()
+	}
+
+	return true
+}
+
+// Len returns the number of queued items, due or not
+func (q *DelayQueue[T]) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return len(q.items)
+}
+
+// Poll removes and returns the earliest item if it is due, without waiting
+func (q *DelayQueue[T]) Poll() (T, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	v, _, ok := q.poll()
+	return v, ok
+}
+
+// poll returns the earliest item if it is due, otherwise how long until it is due
+func (q *DelayQueue[T]) poll() (T, datetime.Duration, bool) {
+	var zero T
+	if len(q.items) == 0 {
+		return zero, -1, false
+	}
+
+	if wait := q.items[0].deadline.Sub(q.clock.Now()); wait > 0 {
+		return zero, wait, false
+	}
+
+	it, _ := heap.Pop(&q.items).(*Item[T])
+	return it.Value, 0, true
+}
+
+// notify wakes up waiting Take calls
+func (q *DelayQueue[T]) notify() {
+	close(q.changed)
+	q.changed = make(chan struct{})
+}
+
+/*
+Take removes and returns the earliest item, waiting until it is due.
+
+Take returns the error of ctx if it is done first.
+*/
+func (q *DelayQueue[T]) Take(ctx context.Context) (T, error) {
+	for {
+		q.mu.Lock()
+		v, wait, ok := q.poll()
+		changed := q.changed
+		q.mu.Unlock()
+
+		if ok {
+			return v, nil
+		}
+
+		// Wait for the earliest item, or for a different earliest item
+		var due chan struct{}
+		stop := func() bool { return false }
+		if wait > 0 {
+			due = make(chan struct{})
+			stop = q.clock.AfterFunc(wait, func() { close(due) })
+		}
+
+		select {
+		case <-ctx.Done():
+			stop()
+			var zero T
+			return zero, ctx.Err()
+		case <-changed:
+			stop()
+		case <-due:
+		}
+	}
+}
+
+func (h itemHeap[T]) Len() int {
+	return len(h)
+}
+
+func (h itemHeap[T]) Less(i, j int) bool {
+	if h[i].deadline != h[j].deadline {
+		return h[i].deadline < h[j].deadline
+	}
+	return h[i].seq < h[j].seq
+}
+
+func (h itemHeap[T]) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *itemHeap[T]) Push(x any) {
+	it, _ := x.(*Item[T])
+	it.index = len(*h)
+	*h = append(*h, it)
+}
+
+func (h *itemHeap[T]) Pop() any {
+	old := *h
+	it := old[len(old)-1]
+	old[len(old)-1] = nil
+	*h = old[:len(old)-1]
+	it.index = -1
+	return it
+}
@@ -0,0 +1,247 @@
+package delayqueue_test
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ram-nad/go-utils/datetime"
+	"github.com/ram-nad/go-utils/datetime/delayqueue"
+)
+
+const start = datetime.ClockTime(1_000_000_000)
+
+// waitForWaiters waits until n calls are scheduled on the clock
+func waitForWaiters(t *testing.T, clock *datetime.ManualClock, n int) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for clock.Waiters() != n {
+		if time.Now().After(deadline) {
+			t.Fatalf("Waiters() = %v, want %v", clock.Waiters(), n)
+		}
+		runtime.Gosched()
+	}
+}
+
+// take runs Take in a goroutine and returns channel receiving its result
+func take(ctx context.Context, q *delayqueue.DelayQueue[string]) <-chan string {
+	result := make(chan string, 1)
+	go func() {
+		v, err := q.Take(ctx)
+		if err != nil {
+			v = err.Error()
+		}
+		result <- v
+	}()
+	return result
+}
+
+func TestPoll(t *testing.T) {
+	clock := datetime.NewManualClock(start)
+	q := delayqueue.New[string](clock)
+
+	q.Put("c", start.Add(datetime.Seconds(3)))
+	q.Put("a", start.Add(datetime.Seconds(1)))
+	q.Put("b", start.Add(datetime.Seconds(2)))
+	q.Put("b2", start.Add(datetime.Seconds(2)))
+
+	if v, ok := q.Poll(); ok {
+		t.Fatalf("Poll() = %q before any deadline", v)
+	}
+
+	clock.Advance(datetime.Seconds(2))
+
+	var got []string
+	for v, ok := q.Poll(); ok; v, ok = q.Poll() {
+		got = append(got, v)
+	}
+
+	if fmt.Sprint(got) != "[a b b2]" || q.Len() != 1 {
+		t.Errorf("Poll() = %v with %d left, want [a b b2] with 1 left", got, q.Len())
+	}
+}
+
+func TestRemove(t *testing.T) {
+	clock := datetime.NewManualClock(start)
+	q := delayqueue.New[int](clock)
+
+	items := make([]*delayqueue.Item[int], 10)
+	for i := range items {
+		items[i] = q.Put(i, start.Add(datetime.Seconds(int64(10-i))))
+	}
+
+	for i := 0; i < len(items); i += 3 {
+		if !q.Remove(items[i]) {
+			t.Errorf("Remove(%d) = false for queued item", i)
+		}
+
+		if q.Remove(items[i]) {
+			t.Errorf("Remove(%d) = true for removed item", i)
+		}
+	}
+
+	clock.Advance(datetime.Seconds(10))
+
+	var got []int
+	for v, ok := q.Poll(); ok; v, ok = q.Poll() {
+		got = append(got, v)
+	}
+
+	if fmt.Sprint(got) != "[8 7 5 4 2 1]" {
+		t.Errorf("Poll() = %v, want [8 7 5 4 2 1]", got)
+	}
+
+	if q.Remove(items[1]) {
+		t.Errorf("Remove() = true for taken item")
+	}
+
+	other := delayqueue.New[int](clock)
+	other.Put(0, start)
+	if other.Remove(items[2]) {
+		t.Errorf("Remove() = true for item of another queue")
+	}
+}
+
+func TestTakeWaitsForDeadline(t *testing.T) {
+	clock := datetime.NewManualClock(start)
+	q := delayqueue.New[string](clock)
+
+	q.Put("a", start.Add(datetime.Seconds(5)))
+	result := take(context.Background(), q)
+
+	waitForWaiters(t, clock, 1)
+	clock.Advance(datetime.Seconds(4))
+
+	select {
+	case v := <-result:
+		t.Fatalf("Take() = %q before deadline", v)
+	default:
+	}
+
+	clock.Advance(datetime.Seconds(1))
+	if v := <-result; v != "a" {
+		t.Errorf("Take() = %q, want a", v)
+	}
+}
+
+func TestTakeWakesOnEarlierItem(t *testing.T) {
+	clock := datetime.NewManualClock(start)
+	q := delayqueue.New[string](clock)
+
+	// Waiting on an empty queue doesn't schedule anything
+	result := take(context.Background(), q)
+	q.Put("late", start.Add(datetime.Minutes(1)))
+	waitForWaiters(t, clock, 1)
+
+	q.Put("early", start.Add(datetime.Seconds(1)))
+	waitForWaiters(t, clock, 1)
+
+	clock.Advance(datetime.Seconds(1))
+	if v := <-result; v != "early" {
+		t.Errorf("Take() = %q, want early", v)
+	}
+}
+
+func TestTakeWakesOnRemove(t *testing.T) {
+	clock := datetime.NewManualClock(start)
+	q := delayqueue.New[string](clock)
+
+	first := q.Put("a", start.Add(datetime.Seconds(10)))
+	q.Put("b", start.Add(datetime.Seconds(20)))
+
+	result := take(context.Background(), q)
+	waitForWaiters(t, clock, 1)
+
+	clock.Advance(datetime.Seconds(5))
+	q.Remove(first)
+	waitForWaiters(t, clock, 1)
+
+	clock.Advance(datetime.Seconds(15))
+	if v := <-result; v != "b" {
+		t.Errorf("Take() = %q, want b", v)
+	}
+}
+
+func TestTakeContext(t *testing.T) {
+	clock := datetime.NewManualClock(start)
+	q := delayqueue.New[string](clock)
+	q.Put("a", start.Add(datetime.Hours(1)))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	result := take(ctx, q)
+	waitForWaiters(t, clock, 1)
+
+	cancel()
+	if v := <-result; v != context.Canceled.Error() {
+		t.Errorf("Take() = %q, want %v", v, context.Canceled)
+	}
+
+	waitForWaiters(t, clock, 0)
+	if q.Len() != 1 {
+		t.Errorf("Len() = %v, want 1", q.Len())
+	}
+}
+
+func TestConcurrentTake(t *testing.T) {
+	q := delayqueue.New[int](datetime.SystemClock{})
+	now := datetime.NowClock()
+
+	const items = 1000
+	for i := range items {
+		q.Put(i, now.Add(datetime.Microseconds(int64(i%50))))
+	}
+
+	var (
+		mu   sync.Mutex
+		seen = make(map[int]bool)
+		wg   sync.WaitGroup
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	for range 8 {
+		wg.Go(func() {
+			for {
+				v, err := q.Take(ctx)
+				if err != nil {
+					return
+				}
+
+				mu.Lock()
+				seen[v] = true
+				if len(seen) == items {
+					cancel()
+				}
+				mu.Unlock()
+			}
+		})
+	}
+
+	wg.Wait()
+	if len(seen) != items || q.Len() != 0 {
+		t.Errorf("took %d items with %d left, want %d", len(seen), q.Len(), items)
+	}
+}
+
+func ExampleDelayQueue() {
+	clock := datetime.NewManualClock(0)
+	q := delayqueue.New[string](clock)
+
+	q.Put("second", clock.Now().Add(datetime.Seconds(2)))
+	q.Put("first", clock.Now().Add(datetime.Seconds(1)))
+
+	for range 3 {
+		clock.Advance(datetime.Seconds(1))
+		v, ok := q.Poll()
+		_, _ = fmt.Println(v, ok)
+	}
+	// Output:
+	// first true
+	// second true
+	//  false
+}
@@ -0,0 +1,186 @@
+package datetime
+
+/*
+Calendar difference between two times.
+
+Diff(from, to) is computed as follows, with start and end being the earlier and the
+later of the two times:
+
+ 1. Whole months is the largest m for which start.AddMonths(m, ClampToMonthEnd) is not
+    after end. Years and Months are m / 12 and m % 12.
+ 2. The remainder from start.AddMonths(m, ClampToMonthEnd) to end is split into Days,
+    Hours, Minutes, Seconds and Nanoseconds. Days are always 24 hours long in UTC.
+ 3. If to is before from, every component is negated.
+
+Clamping to the end of the month means that from Jan 31 one month is reached on
+Feb 28 (or 29), and from Feb 29 one year is reached on Feb 28 of the next year.
+MonthsBetween and YearsBetween count whole units using the same rule.
+*/
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Period is a calendar difference between two times
+type Period struct {
+	Years       int
+	Months      int
+	Days        int
+	Hours       int
+	Minutes     int
+	Seconds     int
+	Nanoseconds int
+}
+
+const hoursPerDay = 24
+
+// wholeMonths returns number of whole months from start to end, start <= end
+func wholeMonths(start, end Time) int {
+	years, months := end.Year()-start.Year(), int(end.Month()-start.Month())
+	m := years*monthsPerYear + months
+
+	// Clamping never fails, error can be ignored
+	if reached, _ := start.AddMonths(m, ClampToMonthEnd); reached.After(end) {
+		m--
+	}
+
+	return m
+}
+
+// Diff returns the calendar difference from from to to
+func Diff(from, to Time) Period {
+	start, end, sign := from, to, 1
+	if to.Before(from) {
+		start, end, sign = to, from, -1
+	}
+
+	m := wholeMonths(start, end)
+	reached, _ := start.AddMonths(m, ClampToMonthEnd)
+	rest := end.Sub(reached)
+
+	days := rest / Hours(hoursPerDay)
+	rest -= days * Hours(hoursPerDay)
+	hours := rest / Hours(1)
+	rest -= hours * Hours(1)
+	minutes := rest / Minutes(1)
+	rest -= minutes * Minutes(1)
+	seconds := rest / Seconds(1)
+	rest -= seconds * Seconds(1)
+
+	return Period{
+		Years:       sign * (m / monthsPerYear),
+		Months:      sign * (m % monthsPerYear),
+		Days:        sign * int(days),
+		Hours:       sign * int(hours),
+		Minutes:     sign * int(minutes),
+		Seconds:     sign * int(seconds),
+		Nanoseconds: sign * int(rest),
+	}
+}
+
+// MonthsBetween returns number of whole months between from and to
+//
+// Result is negative if to is before from.
+func MonthsBetween(from, to Time) int {
+	if to.Before(from) {
+		return -wholeMonths(to, from)
+	}
+	return wholeMonths(from, to)
+}
+
+// YearsBetween returns number of whole years between from and to
+//
+// Result is negative if to is before from.
+func YearsBetween(from, to Time) int {
+	return MonthsBetween(from, to) / monthsPerYear
+}
+
+// DaysBetween returns number of whole days between from and to
+//
+// Result is negative if to is before from.
+func DaysBetween(from, to Time) int {
+	return int(to.Sub(from) / Hours(hoursPerDay))
+}
+
+// IsZero reports whether all components of the period are zero
+func (p Period) IsZero() bool {
+	return p == Period{}
+}
+
+// Negative reports whether the period goes backwards in time
+func (p Period) Negative() bool {
+	return p.Years < 0 || p.Months < 0 || p.Days < 0 || p.Hours < 0 || p.Minutes < 0 ||
+		p.Seconds < 0 || p.Nanoseconds < 0
+}
+
+/*
+String returns the period as ISO 8601 duration, for example "P1Y2M3DT4H5M6.5S".
+
+Negative periods are prefixed with "-" and a zero period is "PT0S".
+*/
+func (p Period) String() string {
+	if p.IsZero() {
+		return "PT0S"
+	}
+
+	if p.Negative() {
+		return "-" + p.negate().String()
+	}
+
+	var sb strings.Builder
+	sb.WriteByte('P')
+	writeComponent(&sb, p.Years, 'Y')
+	writeComponent(&sb, p.Months, 'M')
+	writeComponent(&sb, p.Days, 'D')
+
+	if p.Hours != 0 || p.Minutes != 0 || p.Seconds != 0 || p.Nanoseconds != 0 {
+		sb.WriteByte('T')
+		writeComponent(&sb, p.Hours, 'H')
+		writeComponent(&sb, p.Minutes, 'M')
+
+		if p.Seconds != 0 || p.Nanoseconds != 0 {
+			sb.WriteString(strconv.Itoa(p.Seconds))
+			var frac [maxFracDigits + 1]byte
+			n := putFraction(frac[:], p.Nanoseconds, maxFracDigits, true)
+			sb.Write(frac[:n])
+			sb.WriteByte('S')
+		}
+	}
+
+	return sb.String()
+}
+
+func (p Period) negate() Period {
+	return Period{
+		Years:       -p.Years,
+		Months:      -p.Months,
+		Days:        -p.Days,
+		Hours:       -p.Hours,
+		Minutes:     -p.Minutes,
+		Seconds:     -p.Seconds,
+		Nanoseconds: -p.Nanoseconds,
+	}
+}
+
+func writeComponent(sb *strings.Builder, v int, designator byte) {
+	if v != 0 {
+		sb.WriteString(strconv.Itoa(v))
+		sb.WriteByte(designator)
+	}
+}
+
+/*
+AddTo returns t moved by the period, months are added with ClampToMonthEnd.
+
+Diff(from, to).AddTo(from) equals to when to is not before from.
+*/
+func (p Period) AddTo(t Time) Time {
+	// Clamping never fails, error can be ignored
+	t, _ = t.AddMonths(p.Years*monthsPerYear+p.Months, ClampToMonthEnd)
+
+	rest := Hours(int64(p.Days*hoursPerDay+p.Hours)) + Minutes(int64(p.Minutes)) +
+		Seconds(int64(p.Seconds)) + Nanoseconds(int64(p.Nanoseconds))
+
+	return t.Add(rest)
+}
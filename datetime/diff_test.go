@@ -0,0 +1,189 @@
+package datetime_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ram-nad/go-utils/datetime"
+)
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		from     datetime.Time
+		to       datetime.Time
+		expected datetime.Period
+	}{
+		{
+			datetime.Date(1990, 5, 17, 0, 0, 0, 0),
+			datetime.Date(2021, 8, 19, 13, 45, 12, 500),
+			datetime.Period{
+				Years:       31,
+				Months:      3,
+				Days:        2,
+				Hours:       13,
+				Minutes:     45,
+				Seconds:     12,
+				Nanoseconds: 500,
+			},
+		},
+		{
+			datetime.Date(2021, 1, 31, 0, 0, 0, 0),
+			datetime.Date(2021, 2, 28, 0, 0, 0, 0),
+			datetime.Period{Months: 1},
+		},
+		{
+			datetime.Date(2021, 1, 31, 0, 0, 0, 0),
+			datetime.Date(2021, 3, 1, 0, 0, 0, 0),
+			datetime.Period{Months: 1, Days: 1},
+		},
+		{
+			datetime.Date(2021, 1, 30, 12, 0, 0, 0),
+			datetime.Date(2021, 2, 28, 6, 0, 0, 0),
+			datetime.Period{Days: 28, Hours: 18},
+		},
+		{
+			datetime.Date(2020, 2, 29, 0, 0, 0, 0),
+			datetime.Date(2021, 2, 28, 0, 0, 0, 0),
+			datetime.Period{Years: 1},
+		},
+		{
+			datetime.Date(2021, 3, 15, 0, 0, 0, 0),
+			datetime.Date(2021, 1, 10, 0, 0, 0, 0),
+			datetime.Period{Months: -2, Days: -5},
+		},
+		{
+			datetime.Date(2021, 3, 15, 0, 0, 0, 0),
+			datetime.Date(2021, 3, 15, 0, 0, 0, 0),
+			datetime.Period{},
+		},
+	}
+
+	for _, tc := range tests {
+		got := datetime.Diff(tc.from, tc.to)
+		if got != tc.expected {
+			t.Errorf("Diff(%v, %v) = %+v, want %+v", tc.from, tc.to, got, tc.expected)
+		}
+
+		if !tc.to.Before(tc.from) {
+			if back := got.AddTo(tc.from); !back.Equal(tc.to) {
+				t.Errorf("Diff(%v, %v).AddTo(from) = %v", tc.from, tc.to, back)
+			}
+		}
+	}
+}
+
+func TestDiffAddToRoundTrip(t *testing.T) {
+	from := datetime.Date(2019, 12, 31, 7, 0, 0, 0)
+
+	for to := from; to.Before(datetime.Date(2022, 1, 1, 0, 0, 0, 0)); to = to.Add(
+		datetime.Hours(13),
+	) {
+		if back := datetime.Diff(from, to).AddTo(from); !back.Equal(to) {
+			t.Fatalf("Diff(%v, %v).AddTo(from) = %v", from, to, back)
+		}
+	}
+}
+
+func TestWholeUnitsBetween(t *testing.T) {
+	tests := []struct {
+		from   datetime.Time
+		to     datetime.Time
+		months int
+		years  int
+		days   int
+	}{
+		{
+			datetime.Date(2021, 1, 31, 0, 0, 0, 0),
+			datetime.Date(2021, 2, 28, 0, 0, 0, 0),
+			1, 0, 28,
+		},
+		{
+			datetime.Date(2021, 1, 31, 0, 0, 0, 1),
+			datetime.Date(2021, 2, 28, 0, 0, 0, 0),
+			0, 0, 27,
+		},
+		{
+			datetime.Date(2020, 2, 29, 0, 0, 0, 0),
+			datetime.Date(2024, 2, 28, 0, 0, 0, 0),
+			47, 3, 1460,
+		},
+		{
+			datetime.Date(2024, 2, 28, 0, 0, 0, 0),
+			datetime.Date(2020, 2, 29, 0, 0, 0, 0),
+			-47, -3, -1460,
+		},
+		{
+			datetime.Date(2021, 5, 10, 0, 0, 0, 0),
+			datetime.Date(2021, 5, 10, 23, 0, 0, 0),
+			0, 0, 0,
+		},
+	}
+
+	for _, tc := range tests {
+		if got := datetime.MonthsBetween(tc.from, tc.to); got != tc.months {
+			t.Errorf(
+				"MonthsBetween(%v, %v) = %d, want %d",
+				tc.from,
+				tc.to,
+				got,
+				tc.months,
+			)
+		}
+
+		if got := datetime.YearsBetween(tc.from, tc.to); got != tc.years {
+			t.Errorf(
+				"YearsBetween(%v, %v) = %d, want %d",
+				tc.from,
+				tc.to,
+				got,
+				tc.years,
+			)
+		}
+
+		if got := datetime.DaysBetween(tc.from, tc.to); got != tc.days {
+			t.Errorf("DaysBetween(%v, %v) = %d, want %d", tc.from, tc.to, got, tc.days)
+		}
+	}
+}
+
+func TestPeriodString(t *testing.T) {
+	tests := []struct {
+		period   datetime.Period
+		expected string
+	}{
+		{datetime.Period{}, "PT0S"},
+		{
+			datetime.Period{
+				Years:   1,
+				Months:  2,
+				Days:    3,
+				Hours:   4,
+				Minutes: 5,
+				Seconds: 6,
+			},
+			"P1Y2M3DT4H5M6S",
+		},
+		{datetime.Period{Days: 10}, "P10D"},
+		{datetime.Period{Minutes: 30}, "PT30M"},
+		{datetime.Period{Nanoseconds: 500_000_000}, "PT0.5S"},
+		{datetime.Period{Months: -2, Days: -5}, "-P2M5D"},
+	}
+
+	for _, tc := range tests {
+		if got := tc.period.String(); got != tc.expected {
+			t.Errorf("%+v.String() = %q, want %q", tc.period, got, tc.expected)
+		}
+	}
+}
+
+func ExampleDiff() {
+	birth := datetime.Date(1990, 5, 17, 0, 0, 0, 0)
+	now := datetime.Date(2021, 8, 19, 0, 0, 0, 0)
+
+	age := datetime.Diff(birth, now)
+	_, _ = fmt.Printf("%d years, %d months, %d days\n", age.Years, age.Months, age.Days)
+	_, _ = fmt.Println(age)
+	// Output:
+	// 31 years, 3 months, 2 days
+	// P31Y3M2D
+}
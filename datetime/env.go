@@ -0,0 +1,142 @@
+package datetime
+
+/*
+Binding environment variables to struct fields.
+
+Fields are bound with an env tag naming the variable, and may have a default:
+
+	type Config struct {
+		Timeout  datetime.Duration  `env:"TIMEOUT" default:"30s"`
+		Retain   datetime.Duration  `env:"RETAIN,required"`
+		Cutoff   datetime.Time      `env:"CUTOFF"`
+		Birthday datetime.LocalDate `env:"BIRTHDAY"`
+	}
+
+A field can be of any type whose pointer implements flag.Value (like Duration and
+Time) or encoding.TextUnmarshaler (like LocalDate). Fields without an env tag are left
+alone, except structs which are bound recursively. Empty variables are treated as not
+set.
+
+All problems are reported together, each one as an *EnvError.
+*/
+
+import (
+	"encoding"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+)
+
+var (
+	// ErrEnvRequired is returned when a required environment variable isn't set
+	ErrEnvRequired = errors.New("datetime: environment variable is required")
+	// ErrUnsupportedField is returned for env tags on fields that can't be set from text
+	ErrUnsupportedField = errors.New("datetime: field can't be set from text")
+)
+
+// EnvError is a problem with an environment variable bound to a struct field
+type EnvError struct {
+	Var   string
+	Field string
+	Value string
+	Err   error
+}
+
+// Error returns description of the problem
+func (e *EnvError) Error() string {
+	if errors.Is(e.Err, ErrEnvRequired) || errors.Is(e.Err, ErrUnsupportedField) {
+		return fmt.Sprintf("%s (field %s): %v", e.Var, e.Field, e.Err)
+	}
+	return fmt.Sprintf("%s=%q (field %s): %v", e.Var, e.Value, e.Field, e.Err)
+}
+
+// Unwrap returns the underlying error
+func (e *EnvError) Unwrap() error {
+	return e.Err
+}
+
+// BindEnv sets fields of the struct pointed to by dst from environment variables
+func BindEnv(dst any) error {
+	return BindEnvLookup(dst, os.LookupEnv)
+}
+
+// BindEnvLookup is like BindEnv, but variables are looked up with lookup
+func BindEnvLookup(dst any, lookup func(string) (string, bool)) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("datetime: BindEnv needs a pointer to a struct, got %T", dst)
+	}
+
+	return errors.Join(bindStruct(v.Elem(), "", lookup)...)
+}
+
+// bindStruct binds fields of the struct v, prefix is path of v for error messages
+func bindStruct(
+	v reflect.Value,
+	prefix string,
+	lookup func(string) (string, bool),
+) []error {
+	var errs []error
+
+	for i := range v.NumField() {
+		field := v.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		fv := v.Field(i)
+		tag, ok := field.Tag.Lookup("env")
+		if !ok {
+			if fv.Kind() == reflect.Struct && textSetter(fv) == nil {
+				errs = append(errs, bindStruct(fv, prefix+field.Name+".", lookup)...)
+			}
+			continue
+		}
+
+		name, options, _ := strings.Cut(tag, ",")
+		e := &EnvError{Var: name, Field: prefix + field.Name}
+
+		value, ok := lookup(name)
+		if !ok || value == "" {
+			value, ok = field.Tag.Lookup("default")
+		}
+
+		if !ok {
+			if options == "required" {
+				e.Err = ErrEnvRequired
+				errs = append(errs, e)
+			}
+			continue
+		}
+
+		e.Value = value
+		if e.Err = setFromText(fv, value); e.Err != nil {
+			errs = append(errs, e)
+		}
+	}
+
+	return errs
+}
+
+// textSetter returns a function setting v from text, or nil if v can't be set so
+func textSetter(v reflect.Value) func(string) error {
+	switch p := v.Addr().Interface().(type) {
+	case flag.Value:
+		return p.Set
+	case encoding.TextUnmarshaler:
+		return func(s string) error { return p.UnmarshalText([]byte(s)) }
+	default:
+		return nil
+	}
+}
+
+func setFromText(v reflect.Value, s string) error {
+	set := textSetter(v)
+	if set == nil {
+		return fmt.Errorf("%w: %v", ErrUnsupportedField, v.Type())
+	}
+	return set(s)
+}
@@ -0,0 +1,139 @@
+package datetime_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ram-nad/go-utils/datetime"
+)
+
+type retentionConfig struct {
+	Keep  datetime.Duration `env:"KEEP,required"`
+	Purge datetime.Time     `env:"PURGE"`
+}
+
+type serverConfig struct {
+	Timeout   datetime.Duration   `env:"TIMEOUT" default:"30s"`
+	Idle      datetime.Duration   `env:"IDLE" default:"5m"`
+	Cutoff    datetime.Time       `env:"CUTOFF"`
+	Opens     datetime.TimeOfDay  `env:"OPENS" default:"09:00"`
+	Started   datetime.LocalDate  `env:"STARTED"`
+	Received  datetime.OffsetTime `env:"RECEIVED"`
+	Retention retentionConfig
+	Untagged  datetime.Duration
+	name      string //nolint:unused // unexported fields are ignored
+}
+
+func lookupMap(env map[string]string) func(string) (string, bool) {
+	return func(name string) (string, bool) {
+		v, ok := env[name]
+		return v, ok
+	}
+}
+
+func TestBindEnv(t *testing.T) {
+	env := map[string]string{
+		"TIMEOUT":  "2m",
+		"IDLE":     "",
+		"CUTOFF":   "2021-08-09",
+		"STARTED":  "2021-08-09",
+		"RECEIVED": "2021-08-09T21:37:03+05:30",
+		"KEEP":     "4w",
+	}
+
+	var cfg serverConfig
+	if err := datetime.BindEnvLookup(&cfg, lookupMap(env)); err != nil {
+		t.Fatalf("BindEnvLookup() = %v", err)
+	}
+
+	if cfg.Timeout != datetime.Minutes(2) || cfg.Idle != datetime.Minutes(5) {
+		t.Errorf("Timeout, Idle = %v, %v, want 2m, 5m", cfg.Timeout, cfg.Idle)
+	}
+
+	if !cfg.Cutoff.Equal(datetime.Date(2021, 8, 9, 0, 0, 0, 0)) {
+		t.Errorf("Cutoff = %v", cfg.Cutoff)
+	}
+
+	if cfg.Opens.String() != "09:00:00" || cfg.Started.String() != "2021-08-09" {
+		t.Errorf("Opens, Started = %v, %v", cfg.Opens, cfg.Started)
+	}
+
+	if cfg.Received.Offset() != datetime.Minutes(330) {
+		t.Errorf("Received = %v", cfg.Received)
+	}
+
+	if cfg.Retention.Keep != datetime.Hours(24*28) ||
+		!cfg.Retention.Purge.Equal(datetime.Time{}) {
+		t.Errorf("Retention = %+v", cfg.Retention)
+	}
+
+	if cfg.Untagged != 0 {
+		t.Errorf("Untagged = %v, want 0", cfg.Untagged)
+	}
+}
+
+func TestBindEnvErrors(t *testing.T) {
+	env := map[string]string{
+		"TIMEOUT": "forever",
+		"CUTOFF":  "yesterday",
+		"OPENS":   "25:00",
+	}
+
+	var cfg serverConfig
+	err := datetime.BindEnvLookup(&cfg, lookupMap(env))
+
+	if !errors.Is(err, datetime.ErrEnvRequired) ||
+		!errors.Is(err, datetime.ErrInvalidSyntax) ||
+		!errors.Is(err, datetime.ErrOutOfRange) {
+		t.Fatalf("BindEnvLookup() = %v", err)
+	}
+
+	// Every problem is reported
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		t.Fatalf("BindEnvLookup() = %v, want joined errors", err)
+	}
+
+	var vars []string
+	for _, e := range joined.Unwrap() {
+		var envErr *datetime.EnvError
+		if !errors.As(e, &envErr) {
+			t.Fatalf("Error %v is not an EnvError", e)
+		}
+		vars = append(vars, envErr.Var+" "+envErr.Field)
+	}
+
+	expected := []string{
+		"TIMEOUT Timeout",
+		"CUTOFF Cutoff",
+		"OPENS Opens",
+		"KEEP Retention.Keep",
+	}
+	if len(vars) != len(expected) {
+		t.Fatalf("Errors for %v, want %v", vars, expected)
+	}
+
+	for i := range vars {
+		if vars[i] != expected[i] {
+			t.Errorf("Errors for %v, want %v", vars, expected)
+		}
+	}
+
+	// Fields are still set when other fields fail
+	if cfg.Idle != datetime.Minutes(5) {
+		t.Errorf("Idle = %v, want 5m", cfg.Idle)
+	}
+
+	var unsupported struct {
+		Port int `env:"PORT"`
+	}
+	port := map[string]string{"PORT": "80"}
+	err = datetime.BindEnvLookup(&unsupported, lookupMap(port))
+	if !errors.Is(err, datetime.ErrUnsupportedField) {
+		t.Errorf("BindEnvLookup(int field) = %v, want ErrUnsupportedField", err)
+	}
+
+	if err := datetime.BindEnv(cfg); err == nil {
+		t.Errorf("Expected error when binding to a non pointer")
+	}
+}
@@ -0,0 +1,292 @@
+package datetime
+
+/*
+Conversions between Time and timestamp representations used by other systems.
+
+	Representation  Unit                 Epoch              Range
+	Unix            seconds              1970-01-01         any
+	UnixMilli       milliseconds         1970-01-01         any
+	UnixMicro       microseconds         1970-01-01         any
+	UnixNano        nanoseconds          1970-01-01         1677-09-21 to 2262-04-11
+	NTP             32.32 fixed point s  1900-01-01         1900-01-01 to 2036-02-07
+	FileTime        100 nanoseconds      1601-01-01         1601-01-01 to 60056-05-28
+	DotNetTicks     100 nanoseconds      0001-01-01         0001-01-01 to 9999-12-31
+	ExcelSerial     days (float)         1899-12-30         1900-01-01 to 9999-12-31
+	JulianDay       days (float)         -4713-11-24 12:00  any, about 50µs precision
+	Cocoa           seconds (float)      2001-01-01         any, rounded to µs
+
+Conversions to Time never lose precision for integer representations. Conversions from
+Time truncate towards the epoch's unit, except NTP which rounds up so that converting
+back yields the original Time. Float representations and Julian Day Numbers return
+ErrEpochRange beyond about 146 billion years from 1970.
+*/
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+// ErrEpochRange is returned when a value can't be represented in the target epoch
+var ErrEpochRange = errors.New("datetime: value out of range of epoch representation")
+
+// Offsets of foreign epochs from Unix epoch in seconds
+const (
+	ntpEpochOffset           = 2208988800  // 1900-01-01
+	fileTimeEpochOffset      = 11644473600 // 1601-01-01
+	dotNetEpochOffset        = 62135596800 // 0001-01-01
+	excelEpochOffset         = 2209161600  // 1899-12-30
+	cocoaEpochOffset         = -978307200  // 2001-01-01, after Unix epoch
+	julianDayUnixEpoch       = 2440587.5   // Julian Day of 1970-01-01 00:00
+	julianDayNumberUnixEpoch = 2440588     // Julian Day Number of 1970-01-01
+	maxDotNetTicks           = 3155378975999999999
+	ticksPerSecond           = 10_000_000 // 100 nanosecond ticks
+	nanosPerTick             = 100
+	ntpFractionBits          = 32
+	excelFirstLeapSerial     = 60 // Excel's nonexistent 1900-02-29
+	excelMaxSerial           = 2958466
+)
+
+// Range of Unix seconds accepted from other epochs, about 146 billion years around
+// 1970. Dates of time.Time overflow near the ends of its int64 seconds.
+const (
+	minUnixSeconds = -1 << 62
+	maxUnixSeconds = 1<<62 - 1
+)
+
+// Thresholds used by FromEpochNumber to detect the unit
+const (
+	maxEpochSeconds = 100_000_000_000         // Year 5138 in seconds, 1973 in ms
+	maxEpochMillis  = 100_000_000_000_000     // Year 5138 in ms, 1973 in µs
+	maxEpochMicros  = 100_000_000_000_000_000 // Year 5138 in µs, 1973 in ns
+)
+
+func UnixMicro(usec int64) Time {
+	// This will always return without monotonic clock
+	return Time(time.UnixMicro(usec).UTC())
+}
+
+func UnixNano(nsec int64) Time {
+	// This will always return without monotonic clock
+	return Time(time.Unix(0, nsec).UTC())
+}
+
+// UnixMilli returns the time as milliseconds elapsed since January 1, 1970 UTC.
+func (t Time) UnixMilli() int64 {
+	return time.Time(t).UnixMilli()
+}
+
+// UnixMicro returns the time as microseconds elapsed since January 1, 1970 UTC.
+func (t Time) UnixMicro() int64 {
+	return time.Time(t).UnixMicro()
+}
+
+/*
+UnixNano returns the time as nanoseconds elapsed since January 1, 1970 UTC.
+
+The result is undefined if the time is outside the range of int64 nanoseconds
+(years 1678 to 2262).
+*/
+func (t Time) UnixNano() int64 {
+	return time.Time(t).UnixNano()
+}
+
+/*
+FromEpochNumber converts a Unix timestamp of unknown unit to Time.
+
+The unit is guessed from the magnitude of v: seconds below 1e11, milliseconds below
+1e14, microseconds below 1e17, and nanoseconds otherwise. This is unambiguous for
+times between years 1973 and 5138. The detected unit is returned as a Duration.
+*/
+func FromEpochNumber(v int64) (Time, Duration) {
+	switch {
+	case v > -maxEpochSeconds && v < maxEpochSeconds:
+		return Unix(v, 0), Seconds(1)
+	case v > -maxEpochMillis && v < maxEpochMillis:
+		return UnixMilli(v), Milliseconds(1)
+	case v > -maxEpochMicros && v < maxEpochMicros:
+		return UnixMicro(v), Microseconds(1)
+	default:
+		return UnixNano(v), Nanoseconds(1)
+	}
+}
+
+// FromNTP converts a 64 bit NTP timestamp (era 0) to Time
+func FromNTP(ts uint64) Time {
+	sec := int64(ts>>ntpFractionBits) - ntpEpochOffset
+	frac := ts & (1<<ntpFractionBits - 1)
+	nsec := (frac * uint64(time.Second)) >> ntpFractionBits
+
+	return Unix(sec, int64(nsec))
+}
+
+/*
+ToNTP converts the time to a 64 bit NTP timestamp.
+
+Only NTP era 0 (1900-01-01 to 2036-02-07) is supported, ErrEpochRange is
+returned otherwise. Fraction is rounded up, so FromNTP returns the original time.
+*/
+func (t Time) ToNTP() (uint64, error) {
+	sec := t.Unix() + ntpEpochOffset
+	if sec < 0 || sec > math.MaxUint32 {
+		return 0, ErrEpochRange
+	}
+
+	nsec := uint64(t.Nanosecond())
+	frac := (nsec<<ntpFractionBits + uint64(time.Second) - 1) / uint64(time.Second)
+
+	return uint64(sec)<<ntpFractionBits + frac, nil
+}
+
+// FromFileTime converts Windows FILETIME (100ns ticks since 1601-01-01) to Time
+func FromFileTime(ft uint64) Time {
+	sec := int64(ft/ticksPerSecond) - fileTimeEpochOffset
+	nsec := int64(ft%ticksPerSecond) * nanosPerTick
+
+	return Unix(sec, nsec)
+}
+
+// ToFileTime converts the time to Windows FILETIME, returns ErrEpochRange before 1601
+func (t Time) ToFileTime() (uint64, error) {
+	sec := t.Unix() + fileTimeEpochOffset
+	if sec < 0 || uint64(sec) > math.MaxUint64/ticksPerSecond-1 {
+		return 0, ErrEpochRange
+	}
+
+	return uint64(sec)*ticksPerSecond + uint64(t.Nanosecond()/nanosPerTick), nil
+}
+
+/*
+FromDotNetTicks converts .NET DateTime ticks (100ns intervals since 0001-01-01)
+to Time. Ticks outside the range of DateTime return ErrEpochRange.
+*/
+func FromDotNetTicks(ticks int64) (Time, error) {
+	if ticks < 0 || ticks > maxDotNetTicks {
+		return Time{}, ErrEpochRange
+	}
+
+	sec := ticks/ticksPerSecond - dotNetEpochOffset
+	nsec := ticks % ticksPerSecond * nanosPerTick
+
+	return Unix(sec, nsec), nil
+}
+
+// ToDotNetTicks converts the time to .NET DateTime ticks
+//
+// ErrEpochRange is returned outside years 1 to 9999.
+func (t Time) ToDotNetTicks() (int64, error) {
+	sec := t.Unix() + dotNetEpochOffset
+	if sec < 0 || sec > maxDotNetTicks/ticksPerSecond {
+		return 0, ErrEpochRange
+	}
+
+	return sec*ticksPerSecond + int64(t.Nanosecond()/nanosPerTick), nil
+}
+
+/*
+fromFloatSeconds converts float seconds since Unix epoch to Time rounded to r.
+
+ErrEpochRange is returned for NaN and seconds beyond minUnixSeconds or maxUnixSeconds.
+*/
+func fromFloatSeconds(sec float64, r Duration) (Time, error) {
+	if math.IsNaN(sec) || sec < minUnixSeconds || sec > maxUnixSeconds {
+		return Time{}, ErrEpochRange
+	}
+
+	whole, frac := math.Modf(sec)
+	t := Unix(int64(whole), int64(math.Round(frac*float64(time.Second))))
+
+	return t.Round(r), nil
+}
+
+// toFloatSeconds converts the time to float seconds since Unix epoch
+func toFloatSeconds(t Time) float64 {
+	return float64(t.Unix()) + float64(t.Nanosecond())/float64(time.Second)
+}
+
+/*
+FromExcelSerial converts Excel serial date (1900 date system) to Time.
+
+Excel incorrectly treats 1900 as a leap year, serials before 60 are corrected for it
+and serial 60 (the nonexistent 1900-02-29) returns ErrEpochRange. Serials outside
+1900-01-01 to 9999-12-31 return ErrEpochRange. Time is rounded to milliseconds.
+*/
+func FromExcelSerial(serial float64) (Time, error) {
+	if math.IsNaN(serial) || serial < 1 || serial >= excelMaxSerial {
+		return Time{}, ErrEpochRange
+	}
+
+	if serial >= excelFirstLeapSerial && serial < excelFirstLeapSerial+1 {
+		return Time{}, ErrEpochRange
+	}
+
+	if serial < excelFirstLeapSerial {
+		serial++
+	}
+
+	return fromFloatSeconds(serial*secondsPerDay-excelEpochOffset, Milliseconds(1))
+}
+
+// ToExcelSerial converts the time to Excel serial date (1900 date system)
+//
+// ErrEpochRange is returned outside years 1900 to 9999.
+func (t Time) ToExcelSerial() (float64, error) {
+	serial := (toFloatSeconds(t) + excelEpochOffset) / secondsPerDay
+
+	if serial < excelFirstLeapSerial+1 {
+		serial--
+	}
+
+	if serial < 1 || serial >= excelMaxSerial {
+		return 0, ErrEpochRange
+	}
+
+	return serial, nil
+}
+
+// FromJulianDay converts Julian Day (days since -4713-11-24 12:00 UTC) to Time
+//
+// Time is rounded to microseconds.
+func FromJulianDay(jd float64) (Time, error) {
+	return fromFloatSeconds((jd-julianDayUnixEpoch)*secondsPerDay, Microseconds(1))
+}
+
+// ToJulianDay converts the time to Julian Day
+func (t Time) ToJulianDay() float64 {
+	return toFloatSeconds(t)/secondsPerDay + julianDayUnixEpoch
+}
+
+/*
+JulianDayNumber returns the Julian Day Number of the date of t, which is the
+Julian Day at noon of that date.
+*/
+func (t Time) JulianDayNumber() int64 {
+	return floorDiv(t.Unix(), secondsPerDay) + julianDayNumberUnixEpoch
+}
+
+/*
+FromJulianDayNumber returns midnight UTC of the date with Julian Day Number jdn, it is
+the inverse of JulianDayNumber.
+
+ErrEpochRange is returned for dates beyond about 146 billion years from 1970.
+*/
+func FromJulianDayNumber(jdn int64) (Time, error) {
+	if jdn < minUnixSeconds/secondsPerDay+julianDayNumberUnixEpoch ||
+		jdn > maxUnixSeconds/secondsPerDay+julianDayNumberUnixEpoch {
+		return Time{}, ErrEpochRange
+	}
+
+	return Unix((jdn-julianDayNumberUnixEpoch)*secondsPerDay, 0), nil
+}
+
+// FromCocoa converts Apple Cocoa time (seconds since 2001-01-01) to Time
+//
+// Time is rounded to microseconds.
+func FromCocoa(sec float64) (Time, error) {
+	return fromFloatSeconds(sec-cocoaEpochOffset, Microseconds(1))
+}
+
+// ToCocoa converts the time to Apple Cocoa time (NSDate timeIntervalSinceReferenceDate)
+func (t Time) ToCocoa() float64 {
+	return toFloatSeconds(t) + cocoaEpochOffset
+}
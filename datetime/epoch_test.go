@@ -0,0 +1,325 @@
+package datetime_test
+
+import (
+	"errors"
+	"math"
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"github.com/ram-nad/go-utils/datetime"
+)
+
+func TestUnixConversions(t *testing.T) {
+	dt := datetime.Date(2021, 10, 1, 7, 20, 0, 123_456_789)
+
+	if got := dt.UnixMilli(); got != 1633072800123 {
+		t.Errorf("UnixMilli() = %d, want 1633072800123", got)
+	}
+
+	if got := dt.UnixMicro(); got != 1633072800123456 {
+		t.Errorf("UnixMicro() = %d, want 1633072800123456", got)
+	}
+
+	if got := dt.UnixNano(); got != 1633072800123456789 {
+		t.Errorf("UnixNano() = %d, want 1633072800123456789", got)
+	}
+
+	if got := datetime.UnixMicro(1633072800123456); !got.Equal(dt.Truncate(1000)) {
+		t.Errorf("UnixMicro() = %v, want %v", got, dt.Truncate(1000))
+	}
+
+	if got := datetime.UnixNano(1633072800123456789); !got.Equal(dt) {
+		t.Errorf("UnixNano() = %v, want %v", got, dt)
+	}
+}
+
+func TestFromEpochNumber(t *testing.T) {
+	dt := datetime.Date(2021, 1, 1, 0, 0, 0, 0)
+
+	tests := []struct {
+		v    int64
+		unit datetime.Duration
+	}{
+		{1609459200, datetime.Seconds(1)},
+		{1609459200000, datetime.Milliseconds(1)},
+		{1609459200000000, datetime.Microseconds(1)},
+		{1609459200000000000, datetime.Nanoseconds(1)},
+	}
+
+	for _, tc := range tests {
+		got, unit := datetime.FromEpochNumber(tc.v)
+		if !got.Equal(dt) || unit != tc.unit {
+			t.Errorf(
+				"FromEpochNumber(%d) = %v, %v, want %v, %v",
+				tc.v,
+				got,
+				unit,
+				dt,
+				tc.unit,
+			)
+		}
+	}
+
+	got, unit := datetime.FromEpochNumber(-1609459200000)
+	if expected := datetime.UnixMilli(-1609459200000); !got.Equal(expected) ||
+		unit != datetime.Milliseconds(1) {
+		t.Errorf("FromEpochNumber(-1609459200000) = %v, %v", got, unit)
+	}
+
+	_, unit = datetime.FromEpochNumber(math.MinInt64)
+	if unit != datetime.Nanoseconds(1) {
+		t.Errorf("FromEpochNumber(MinInt64) detected unit %v, want 1ns", unit)
+	}
+}
+
+func TestNTP(t *testing.T) {
+	dt := datetime.Date(2021, 1, 1, 0, 0, 0, 500_000_000)
+	expected := uint64(3818448000)<<32 | 1<<31
+
+	got, err := dt.ToNTP()
+	if err != nil || got != expected {
+		t.Errorf("ToNTP() = %d, %v, want %d", got, err, expected)
+	}
+
+	if back := datetime.FromNTP(expected); !back.Equal(dt) {
+		t.Errorf("FromNTP(%d) = %v, want %v", expected, back, dt)
+	}
+
+	if back := datetime.FromNTP(0); !back.Equal(datetime.Date(1900, 1, 1, 0, 0, 0, 0)) {
+		t.Errorf("FromNTP(0) = %v, want 1900-01-01", back)
+	}
+
+	for _, outside := range []datetime.Time{
+		datetime.Date(1899, 12, 31, 23, 59, 59, 0),
+		datetime.Date(2036, 2, 7, 6, 28, 16, 0),
+	} {
+		if _, err := outside.ToNTP(); !errors.Is(err, datetime.ErrEpochRange) {
+			t.Errorf("ToNTP() of %v error = %v, want ErrEpochRange", outside, err)
+		}
+	}
+
+	rng := rand.New(rand.NewPCG(3, 4))
+	for range 10_000 {
+		sec := rng.Int64N(1<<32) - 2208988800
+		dt := datetime.Unix(sec, rng.Int64N(int64(time.Second)))
+		ts, err := dt.ToNTP()
+		if err != nil {
+			t.Fatalf("ToNTP() of %v failed with %v", dt, err)
+		}
+
+		if back := datetime.FromNTP(ts); !back.Equal(dt) {
+			t.Fatalf(
+				"FromNTP(ToNTP(%v)) = %v",
+				dt.ISOStringNano(),
+				back.ISOStringNano(),
+			)
+		}
+	}
+}
+
+func TestFileTime(t *testing.T) {
+	dt := datetime.Date(1970, 1, 1, 0, 0, 0, 1234)
+	expected := uint64(116444736000000012)
+
+	got, err := dt.ToFileTime()
+	if err != nil || got != expected {
+		t.Errorf("ToFileTime() = %d, %v, want %d", got, err, expected)
+	}
+
+	if back := datetime.FromFileTime(expected); !back.Equal(dt.Truncate(100)) {
+		t.Errorf("FromFileTime(%d) = %v, want %v", expected, back, dt.Truncate(100))
+	}
+
+	epoch := datetime.Date(1601, 1, 1, 0, 0, 0, 0)
+	if back := datetime.FromFileTime(0); !back.Equal(epoch) {
+		t.Errorf("FromFileTime(0) = %v, want 1601-01-01", back)
+	}
+
+	if back := datetime.FromFileTime(math.MaxUint64); back.Year() != 60056 {
+		t.Errorf("FromFileTime(MaxUint64) = %v, want year 60056", back)
+	}
+
+	before := datetime.Date(1600, 12, 31, 23, 59, 59, 0)
+	if _, err := before.ToFileTime(); !errors.Is(err, datetime.ErrEpochRange) {
+		t.Errorf("ToFileTime() of %v error = %v, want ErrEpochRange", before, err)
+	}
+}
+
+func TestDotNetTicks(t *testing.T) {
+	dt := datetime.Date(1970, 1, 1, 0, 0, 0, 0)
+	expected := int64(621355968000000000)
+
+	got, err := dt.ToDotNetTicks()
+	if err != nil || got != expected {
+		t.Errorf("ToDotNetTicks() = %d, %v, want %d", got, err, expected)
+	}
+
+	back, err := datetime.FromDotNetTicks(expected)
+	if err != nil || !back.Equal(dt) {
+		t.Errorf("FromDotNetTicks(%d) = %v, %v, want %v", expected, back, err, dt)
+	}
+
+	maxValue := datetime.Date(9999, 12, 31, 23, 59, 59, 999_999_900)
+	back, err = datetime.FromDotNetTicks(3155378975999999999)
+	if err != nil || !back.Equal(maxValue) {
+		t.Errorf("FromDotNetTicks(MaxValue) = %v, %v, want %v", back, err, maxValue)
+	}
+
+	for _, ticks := range []int64{-1, 3155378976000000000} {
+		_, err := datetime.FromDotNetTicks(ticks)
+		if !errors.Is(err, datetime.ErrEpochRange) {
+			t.Errorf("FromDotNetTicks(%d) error = %v, want ErrEpochRange", ticks, err)
+		}
+	}
+
+	outside := datetime.Date(10000, 1, 1, 0, 0, 0, 0)
+	if _, err := outside.ToDotNetTicks(); !errors.Is(err, datetime.ErrEpochRange) {
+		t.Errorf("ToDotNetTicks() of %v error = %v, want ErrEpochRange", outside, err)
+	}
+}
+
+func TestExcelSerial(t *testing.T) {
+	tests := []struct {
+		serial float64
+		dt     datetime.Time
+	}{
+		{1, datetime.Date(1900, 1, 1, 0, 0, 0, 0)},
+		{59, datetime.Date(1900, 2, 28, 0, 0, 0, 0)},
+		{61, datetime.Date(1900, 3, 1, 0, 0, 0, 0)},
+		{44197, datetime.Date(2021, 1, 1, 0, 0, 0, 0)},
+		{44197.75, datetime.Date(2021, 1, 1, 18, 0, 0, 0)},
+		{2958465, datetime.Date(9999, 12, 31, 0, 0, 0, 0)},
+	}
+
+	for _, tc := range tests {
+		got, err := datetime.FromExcelSerial(tc.serial)
+		if err != nil || !got.Equal(tc.dt) {
+			t.Errorf(
+				"FromExcelSerial(%v) = %v, %v, want %v",
+				tc.serial,
+				got,
+				err,
+				tc.dt,
+			)
+		}
+
+		serial, err := tc.dt.ToExcelSerial()
+		if err != nil || serial != tc.serial {
+			t.Errorf(
+				"ToExcelSerial() of %v = %v, %v, want %v",
+				tc.dt,
+				serial,
+				err,
+				tc.serial,
+			)
+		}
+	}
+
+	for _, serial := range []float64{0, 60, 60.5, 2958466, math.NaN(), math.Inf(1)} {
+		_, err := datetime.FromExcelSerial(serial)
+		if !errors.Is(err, datetime.ErrEpochRange) {
+			t.Errorf("FromExcelSerial(%v) error = %v, want ErrEpochRange", serial, err)
+		}
+	}
+
+	before := datetime.Date(1899, 12, 31, 0, 0, 0, 0)
+	if _, err := before.ToExcelSerial(); !errors.Is(err, datetime.ErrEpochRange) {
+		t.Errorf("ToExcelSerial() of %v error = %v, want ErrEpochRange", before, err)
+	}
+}
+
+func TestJulianDay(t *testing.T) {
+	j2000 := datetime.Date(2000, 1, 1, 12, 0, 0, 0)
+
+	if got := j2000.ToJulianDay(); got != 2451545.0 {
+		t.Errorf("ToJulianDay() = %v, want 2451545.0", got)
+	}
+
+	got, err := datetime.FromJulianDay(2451545.25)
+	expected := datetime.Date(2000, 1, 1, 18, 0, 0, 0)
+	if err != nil || !got.Equal(expected) {
+		t.Errorf("FromJulianDay(2451545.25) = %v, %v, want %v", got, err, expected)
+	}
+
+	for _, dt := range []datetime.Time{
+		datetime.Date(2000, 1, 1, 0, 0, 0, 0),
+		datetime.Date(2000, 1, 1, 23, 59, 59, 0),
+	} {
+		if got := dt.JulianDayNumber(); got != 2451545 {
+			t.Errorf("JulianDayNumber() of %v = %d, want 2451545", dt, got)
+		}
+	}
+
+	if got := datetime.Date(-4713, 11, 24, 0, 0, 0, 0).JulianDayNumber(); got != 0 {
+		t.Errorf("JulianDayNumber() of -4713-11-24 = %d, want 0", got)
+	}
+
+	for _, jd := range []float64{math.Inf(-1), math.NaN(), 1e16, -1e16} {
+		_, err := datetime.FromJulianDay(jd)
+		if !errors.Is(err, datetime.ErrEpochRange) {
+			t.Errorf("FromJulianDay(%v) error = %v, want ErrEpochRange", jd, err)
+		}
+	}
+}
+
+func TestJulianDayNumber(t *testing.T) {
+	for _, jdn := range []int64{0, 2440588, 2451545, -1_000_000, 100_000_000_000} {
+		got, err := datetime.FromJulianDayNumber(jdn)
+		if err != nil || got.JulianDayNumber() != jdn {
+			t.Errorf("FromJulianDayNumber(%d) = %v, %v", jdn, got, err)
+		}
+
+		if got.Hour() != 0 || got.Minute() != 0 || got.Second() != 0 {
+			t.Errorf("FromJulianDayNumber(%d) = %v, want midnight", jdn, got)
+		}
+	}
+
+	got, err := datetime.FromJulianDayNumber(2451545)
+	expected := datetime.Date(2000, 1, 1, 0, 0, 0, 0)
+	if err != nil || !got.Equal(expected) {
+		t.Errorf("FromJulianDayNumber(2451545) = %v, %v, want %v", got, err, expected)
+	}
+
+	for _, jdn := range []int64{math.MinInt64, math.MaxInt64, 1 << 50} {
+		_, err := datetime.FromJulianDayNumber(jdn)
+		if !errors.Is(err, datetime.ErrEpochRange) {
+			t.Errorf("FromJulianDayNumber(%d) error = %v, want ErrEpochRange", jdn, err)
+		}
+	}
+}
+
+func TestFloatEpochRange(t *testing.T) {
+	// Large finite values must not overflow into a wrong Time
+	for _, sec := range []float64{1e19, -1e19, math.MaxFloat64, 9.3e18} {
+		if _, err := datetime.FromCocoa(sec); !errors.Is(err, datetime.ErrEpochRange) {
+			t.Errorf("FromCocoa(%v) error = %v, want ErrEpochRange", sec, err)
+		}
+	}
+
+	// Far but representable values convert exactly
+	sec := float64(1 << 52)
+	got, err := datetime.FromCocoa(sec)
+	if err != nil || got.ToCocoa() != sec {
+		t.Errorf("FromCocoa(%v) = %v, %v", sec, got, err)
+	}
+}
+
+func TestCocoa(t *testing.T) {
+	dt := datetime.Date(2021, 1, 1, 0, 0, 0, 250_000_000)
+
+	if got := dt.ToCocoa(); got != 631152000.25 {
+		t.Errorf("ToCocoa() = %v, want 631152000.25", got)
+	}
+
+	got, err := datetime.FromCocoa(631152000.25)
+	if err != nil || !got.Equal(dt) {
+		t.Errorf("FromCocoa(631152000.25) = %v, %v, want %v", got, err, dt)
+	}
+
+	got, err = datetime.FromCocoa(-0.5)
+	if expected := datetime.Date(2000, 12, 31, 23, 59, 59, 500_000_000); err != nil ||
+		!got.Equal(expected) {
+		t.Errorf("FromCocoa(-0.5) = %v, %v, want %v", got, err, expected)
+	}
+}
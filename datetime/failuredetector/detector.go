@@ -0,0 +1,213 @@
+/*
+Package failuredetector decides whether a peer is alive from the arrival times of its
+heartbeats, using the phi accrual failure detector of Hayashibara et al.
+
+Instead of a yes or no answer after a fixed timeout, a Detector returns phi, the
+suspicion that the peer failed on a logarithmic scale. Intervals between heartbeats
+are assumed to be normally distributed, with mean and deviation estimated from the
+last WindowSize intervals:
+
+	phi = -log10(P(a heartbeat arrives later than now))
+
+A phi of 1 means a 10% chance of wrongly suspecting the peer, 2 means 1%, 3 means
+0.1% and so on. Thresholds between 8 and 12 are common.
+
+The normal distribution is approximated with a logistic function, like Akka does.
+
+	detector := failuredetector.NewDetector(failuredetector.Config{})
+	detector.Heartbeat(datetime.NowClock())
+	...
+	if !detector.IsAvailable(8) {
+		reconnect()
+	}
+
+A Monitor tracks many peers and reports when they become suspected or alive again.
+*/
+package failuredetector
+
+import (
+	"math"
+	"sync"
+
+	"github.com/ram-nad/go-utils/datetime"
+)
+
+// Config configures a Detector, zero fields get defaults
+type Config struct {
+	// WindowSize is the number of intervals kept, 1000 by default
+	WindowSize int
+	// MinStdDev is the lower bound of the deviation, 100ms by default, it keeps very
+	// regular heartbeats from making phi too sensitive
+	MinStdDev datetime.Duration
+	// AcceptablePause is added to the mean interval, to tolerate pauses like garbage
+	// collection
+	AcceptablePause datetime.Duration
+	// FirstInterval is the expected interval before any were observed, 1s by default
+	FirstInterval datetime.Duration
+	// Clock is used by IsAvailable, nil means datetime.SystemClock
+	Clock datetime.Clock
+}
+
+// Detector estimates whether a peer is alive from its heartbeats, it is safe for
+// concurrent use
+type Detector struct {
+	mu     sync.Mutex
+	config Config
+	// intervals is a ring buffer of the last intervals in nanoseconds
+	intervals []float64
+	next      int
+	full      bool
+	sum       float64
+	sumSq     float64
+	last      datetime.ClockTime
+	started   bool
+}
+
+const (
+	defaultWindowSize = 1000
+	// Constants of the logistic approximation of the normal distribution
+	logisticA = 1.5976
+	logisticB = 0.070566
+	// Deviation of the first interval estimate, as a fraction of it
+	firstDeviation = 4
+)
+
+// NewDetector creates a detector with no heartbeats
+func NewDetector(config Config) *Detector {
+	if config.WindowSize <= 0 {
+		config.WindowSize = defaultWindowSize
+	}
+
+	if config.MinStdDev <= 0 {
+		config.MinStdDev = datetime.Milliseconds(100) //nolint:mnd // default
+	}
+
+	if config.FirstInterval <= 0 {
+		config.FirstInterval = datetime.Seconds(1)
+	}
+
+	if config.Clock == nil {
+		config.Clock = datetime.SystemClock{}
+	}
+
+	return &Detector{config: config, intervals: make([]float64, config.WindowSize)}
+}
+
+/*
+Heartbeat records a heartbeat arriving at at.
+
+The first heartbeat seeds the intervals with FirstInterval, give or take a quarter.
+Heartbeats older than the last one are ignored.
+*/
+func (d *Detector) Heartbeat(at datetime.ClockTime) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if !d.started {
+		first := float64(d.config.FirstInterval)
+		d.add(first - first/firstDeviation)
+		d.add(first + first/firstDeviation)
+		d.last, d.started = at, true
+		return
+	}
+
+	if at < d.last {
+		return
+	}
+
+	d.add(float64(at.Sub(d.last)))
+	d.last = at
+}
+
+// add puts an interval into the ring buffer
+func (d *Detector) add(interval float64) {
+	if d.full {
+		old := d.intervals[d.next]
+		d.sum -= old
+		d.sumSq -= old * old
+	}
+
+	d.intervals[d.next] = interval
+	d.sum += interval
+	d.sumSq += interval * interval
+
+	d.next++
+	if d.next < len(d.intervals) {
+		return
+	}
+
+	// Sums are recomputed every rotation, so rounding errors don't accumulate
+	d.next, d.full = 0, true
+	d.sum, d.sumSq = 0, 0
+	for _, v := range d.intervals {
+		d.sum += v
+		d.sumSq += v * v
+	}
+}
+
+// LastHeartbeat returns when the last heartbeat arrived, ok is false before the first
+func (d *Detector) LastHeartbeat() (at datetime.ClockTime, ok bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.last, d.started
+}
+
+// Mean returns the mean interval between heartbeats
+func (d *Detector) Mean() datetime.Duration {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	mean, _ := d.stats()
+	return datetime.Duration(mean)
+}
+
+// stats returns mean and deviation of the intervals in nanoseconds
+func (d *Detector) stats() (mean, stdDev float64) {
+	n := float64(d.next)
+	if d.full {
+		n = float64(len(d.intervals))
+	}
+
+	if n == 0 {
+		return 0, 0
+	}
+
+	mean = d.sum / n
+	variance := max(d.sumSq/n-mean*mean, 0)
+
+	return mean, math.Sqrt(variance)
+}
+
+// Phi returns suspicion that the peer failed by now, zero before the first heartbeat
+func (d *Detector) Phi(now datetime.ClockTime) float64 {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if !d.started {
+		return 0
+	}
+
+	mean, stdDev := d.stats()
+	mean += float64(d.config.AcceptablePause)
+	stdDev = max(stdDev, float64(d.config.MinStdDev))
+
+	return phi(float64(now.Sub(d.last)), mean, stdDev)
+}
+
+// phi returns -log10 of the probability that an interval is longer than elapsed
+func phi(elapsed, mean, stdDev float64) float64 {
+	y := (elapsed - mean) / stdDev
+	e := math.Exp(-y * (logisticA + logisticB*y*y))
+
+	if elapsed > mean {
+		return -math.Log10(e / (1 + e))
+	}
+
+	return -math.Log10(1 - 1/(1+e))
+}
+
+// IsAvailable reports whether phi is below threshold at the time of the clock
+func (d *Detector) IsAvailable(threshold float64) bool {
+	return d.Phi(d.config.Clock.Now()) < threshold
+}
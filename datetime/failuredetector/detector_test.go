@@ -0,0 +1,158 @@
+package failuredetector_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/ram-nad/go-utils/datetime"
+	"github.com/ram-nad/go-utils/datetime/failuredetector"
+)
+
+const start = datetime.ClockTime(1_000_000_000)
+
+// heartbeats feeds n heartbeats every interval starting at from, returns the last
+func heartbeats(
+	d *failuredetector.Detector,
+	from datetime.ClockTime,
+	interval datetime.Duration,
+	n int,
+) datetime.ClockTime {
+	at := from
+	for i := range n {
+		at = from.Add(interval * datetime.Duration(i))
+		d.Heartbeat(at)
+	}
+	return at
+}
+
+func TestPhi(t *testing.T) {
+	d := failuredetector.NewDetector(failuredetector.Config{WindowSize: 100})
+
+	if phi := d.Phi(start.Add(datetime.Hours(1))); phi != 0 {
+		t.Errorf("Phi() = %v before any heartbeat, want 0", phi)
+	}
+
+	last := heartbeats(d, start, datetime.Seconds(1), 101)
+	if d.Mean() != datetime.Seconds(1) {
+		t.Fatalf("Mean() = %v, want 1s", d.Mean())
+	}
+
+	// Deviation is MinStdDev of 100ms
+	tests := []struct {
+		elapsed  datetime.Duration
+		expected float64
+	}{
+		{0, 0},
+		{datetime.Milliseconds(900), 0.0750},
+		{datetime.Seconds(1), 0.3010},
+		{datetime.Milliseconds(1200), 1.6428},
+		{datetime.Milliseconds(1500), 7.2999},
+		{datetime.Seconds(2), 37.5847},
+	}
+
+	for _, tc := range tests {
+		got := d.Phi(last.Add(tc.elapsed))
+		if math.Abs(got-tc.expected) > 0.001*max(tc.expected, 1) {
+			t.Errorf("Phi(+%v) = %.4f, want %.4f", tc.elapsed, got, tc.expected)
+		}
+	}
+}
+
+func TestPhiGrowsWithElapsed(t *testing.T) {
+	d := failuredetector.NewDetector(failuredetector.Config{
+		AcceptablePause: datetime.Milliseconds(500),
+	})
+
+	last := heartbeats(d, start, datetime.Milliseconds(250), 50)
+
+	previous := -1.0
+	for ms := int64(0); ms < 5000; ms += 10 {
+		phi := d.Phi(last.Add(datetime.Milliseconds(ms)))
+		if phi < previous || math.IsNaN(phi) {
+			t.Fatalf("Phi(+%vms) = %v, less than %v before", ms, phi, previous)
+		}
+		previous = phi
+	}
+}
+
+func TestSlidingWindow(t *testing.T) {
+	d := failuredetector.NewDetector(failuredetector.Config{WindowSize: 10})
+
+	last := heartbeats(d, start, datetime.Seconds(1), 20)
+	heartbeats(d, last.Add(datetime.Seconds(3)), datetime.Seconds(3), 10)
+
+	if d.Mean() != datetime.Seconds(3) {
+		t.Errorf("Mean() = %v after window slid, want 3s", d.Mean())
+	}
+}
+
+func TestFirstHeartbeat(t *testing.T) {
+	d := failuredetector.NewDetector(failuredetector.Config{
+		FirstInterval: datetime.Seconds(2),
+	})
+	d.Heartbeat(start)
+
+	if at, ok := d.LastHeartbeat(); !ok || at != start {
+		t.Errorf("LastHeartbeat() = %v, %v", at, ok)
+	}
+
+	if d.Mean() != datetime.Seconds(2) {
+		t.Errorf("Mean() = %v, want 2s", d.Mean())
+	}
+
+	// Late heartbeats are ignored
+	d.Heartbeat(start.Add(-datetime.Seconds(1)))
+	if at, _ := d.LastHeartbeat(); at != start {
+		t.Errorf("LastHeartbeat() = %v after older heartbeat", at)
+	}
+}
+
+func TestIsAvailable(t *testing.T) {
+	clock := datetime.NewManualClock(start)
+	d := failuredetector.NewDetector(failuredetector.Config{Clock: clock})
+
+	for range 10 {
+		d.Heartbeat(clock.Now())
+		clock.Advance(datetime.Seconds(1))
+	}
+
+	if !d.IsAvailable(8) {
+		t.Errorf("IsAvailable(8) = false on time, phi %v", d.Phi(clock.Now()))
+	}
+
+	clock.Advance(datetime.Seconds(1))
+	if d.IsAvailable(8) {
+		t.Errorf("IsAvailable(8) = true 2s late, phi %v", d.Phi(clock.Now()))
+	}
+}
+
+func BenchmarkPhi(b *testing.B) {
+	d := failuredetector.NewDetector(failuredetector.Config{})
+	last := heartbeats(d, start, datetime.Seconds(1), 2000)
+
+	for b.Loop() {
+		d.Heartbeat(last)
+		_ = d.Phi(last.Add(datetime.Seconds(1)))
+	}
+}
+
+func ExampleDetector() {
+	d := failuredetector.NewDetector(failuredetector.Config{})
+
+	now := datetime.ClockTime(0)
+	for range 10 {
+		d.Heartbeat(now)
+		now = now.Add(datetime.Seconds(1))
+	}
+
+	for _, late := range []int64{0, 100, 200, 300} {
+		phi := d.Phi(now.Add(datetime.Milliseconds(late)))
+		_, _ = fmt.Printf("%dms late: phi %.2f\n", late, phi)
+	}
+	// Output:
+	// 0ms late: phi 0.30
+	// 100ms late: phi 0.76
+	// 200ms late: phi 1.52
+	// 300ms late: phi 2.64
+}
@@ -0,0 +1,202 @@
+package failuredetector
+
+/*
+Monitor keeps a Detector per peer and a status derived from it. Heartbeats are
+recorded as they arrive, suspicion is only evaluated by Check, so a peer becomes
+suspected at the first Check after its phi reached the threshold. A heartbeat from a
+suspected peer makes it alive again right away.
+
+Transitions are reported to OnTransition outside of any lock, so it may call the
+Monitor. Calls from concurrent Heartbeat and Check may interleave.
+*/
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ram-nad/go-utils/datetime"
+)
+
+// Status is what a Monitor believes about a peer
+type Status int
+
+// Transition is a change of the status of a peer
+type Transition[K comparable] struct {
+	Peer   K
+	Status Status
+	// Phi is the suspicion when the status changed
+	Phi float64
+	At  datetime.ClockTime
+}
+
+// MonitorConfig configures a Monitor
+type MonitorConfig[K comparable] struct {
+	// Detector configures detectors of peers
+	Detector Config
+	// Threshold is the phi from which a peer is suspected, 8 by default
+	Threshold float64
+	// OnTransition is called when a peer becomes suspected or alive again
+	OnTransition func(Transition[K])
+}
+
+// Monitor tracks liveness of many peers, it is safe for concurrent use
+type Monitor[K comparable] struct {
+	mu     sync.Mutex
+	config MonitorConfig[K]
+	peers  map[K]*peer
+	// pending are transitions not yet passed to OnTransition
+	pending []Transition[K]
+}
+
+// peer is the state of a monitored peer
+type peer struct {
+	detector *Detector
+	status   Status
+}
+
+const (
+	// Alive is the status of peers whose phi is below the threshold
+	Alive Status = iota
+	// Suspect is the status of peers whose phi reached the threshold
+	Suspect
+)
+
+const defaultThreshold = 8
+
+// String returns name of the status
+func (s Status) String() string {
+	switch s {
+	case Alive:
+		return "alive"
+	case Suspect:
+		return "suspect"
+	default:
+		return "unknown"
+	}
+}
+
+// NewMonitor creates a monitor without peers
+func NewMonitor[K comparable](config MonitorConfig[K]) *Monitor[K] {
+	if config.Threshold <= 0 {
+		config.Threshold = defaultThreshold
+	}
+
+	if config.Detector.Clock == nil {
+		config.Detector.Clock = datetime.SystemClock{}
+	}
+
+	return &Monitor[K]{config: config, peers: make(map[K]*peer)}
+}
+
+// Heartbeat records a heartbeat of the peer arriving at at, new peers are alive
+func (m *Monitor[K]) Heartbeat(id K, at datetime.ClockTime) {
+	m.mu.Lock()
+
+	p, ok := m.peers[id]
+	if !ok {
+		p = &peer{detector: NewDetector(m.config.Detector)}
+		m.peers[id] = p
+	}
+
+	p.detector.Heartbeat(at)
+	if p.status == Suspect {
+		m.transition(id, p, Alive, at)
+	}
+
+	m.unlock()
+}
+
+// Remove stops monitoring the peer
+func (m *Monitor[K]) Remove(id K) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.peers, id)
+}
+
+// Status returns status and phi of the peer at now, ok is false for unknown peers
+func (m *Monitor[K]) Status(id K, now datetime.ClockTime) (Status, float64, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	p, ok := m.peers[id]
+	if !ok {
+		return Alive, 0, false
+	}
+
+	return p.status, p.detector.Phi(now), true
+}
+
+// Peers returns the number of monitored peers
+func (m *Monitor[K]) Peers() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return len(m.peers)
+}
+
+// Check suspects alive peers whose phi reached the threshold at now, it returns the
+// number of peers that became suspected
+func (m *Monitor[K]) Check(now datetime.ClockTime) int {
+	m.mu.Lock()
+
+	suspected := 0
+	for id, p := range m.peers {
+		if p.status == Alive && p.detector.Phi(now) >= m.config.Threshold {
+			m.transition(id, p, Suspect, now)
+			suspected++
+		}
+	}
+
+	m.unlock()
+
+	return suspected
+}
+
+// Run calls Check every interval of the detector Clock until ctx is done, it panics
+// if interval isn't positive
+func (m *Monitor[K]) Run(ctx context.Context, interval datetime.Duration) {
+	if interval <= 0 {
+		panic("failuredetector: non-positive interval for Run")
+	}
+
+	clock := m.config.Detector.Clock
+	for {
+		due := make(chan struct{})
+		stop := clock.AfterFunc(interval, func() { close(due) })
+
+		select {
+		case <-ctx.Done():
+			stop()
+			return
+		case <-due:
+			m.Check(clock.Now())
+		}
+	}
+}
+
+// transition changes status of the peer and queues the transition
+func (m *Monitor[K]) transition(id K, p *peer, status Status, at datetime.ClockTime) {
+	p.status = status
+	m.pending = append(m.pending, Transition[K]{
+		Peer:   id,
+		Status: status,
+		Phi:    p.detector.Phi(at),
+		At:     at,
+	})
+}
+
+// unlock unlocks the monitor, then reports queued transitions
+func (m *Monitor[K]) unlock() {
+	pending := m.pending
+	m.pending = nil
+	m.mu.Unlock()
+
+	if m.config.OnTransition == nil {
+		return
+	}
+
+	for _, t := range pending {
+		m.config.OnTransition(t)
+	}
+}
@@ -0,0 +1,175 @@
+package failuredetector_test
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ram-nad/go-utils/datetime"
+	"github.com/ram-nad/go-utils/datetime/failuredetector"
+)
+
+func TestMonitor(t *testing.T) {
+	clock := datetime.NewManualClock(start)
+
+	var transitions []string
+	m := failuredetector.NewMonitor(failuredetector.MonitorConfig[string]{
+		Detector: failuredetector.Config{Clock: clock},
+		OnTransition: func(tr failuredetector.Transition[string]) {
+			transitions = append(
+				transitions,
+				fmt.Sprintf("%s %v at %v", tr.Peer, tr.Status, tr.At.Sub(start)),
+			)
+		},
+	})
+
+	// Both peers send heartbeats every second, b stops after 5 seconds
+	for i := range 10 {
+		m.Heartbeat("a", clock.Now())
+		if i < 5 {
+			m.Heartbeat("b", clock.Now())
+		}
+
+		m.Check(clock.Now())
+		clock.Advance(datetime.Seconds(1))
+	}
+
+	status, phi, ok := m.Status("b", clock.Now())
+	if !ok || status != failuredetector.Suspect {
+		t.Errorf("Status(b) = %v, %v, %v, want suspect", status, phi, ok)
+	}
+
+	if status, _, _ := m.Status("a", clock.Now()); status != failuredetector.Alive {
+		t.Errorf("Status(a) = %v, want alive", status)
+	}
+
+	// b comes back
+	m.Heartbeat("b", clock.Now())
+	if n := m.Check(clock.Now()); n != 0 {
+		t.Errorf("Check() = %v after b came back, want 0", n)
+	}
+
+	expected := "[b suspect at 6s b alive at 10s]"
+	if fmt.Sprint(transitions) != expected {
+		t.Errorf("transitions %v, want %v", transitions, expected)
+	}
+
+	m.Remove("b")
+	if _, _, ok := m.Status("b", clock.Now()); ok || m.Peers() != 1 {
+		t.Errorf("Status(b) found after Remove, %d peers", m.Peers())
+	}
+}
+
+func TestMonitorThreshold(t *testing.T) {
+	m := failuredetector.NewMonitor(failuredetector.MonitorConfig[int]{Threshold: 1})
+
+	for i := range int64(10) {
+		m.Heartbeat(1, start.Add(datetime.Seconds(i)))
+	}
+
+	last := start.Add(datetime.Seconds(9))
+	if n := m.Check(last.Add(datetime.Seconds(1))); n != 0 {
+		t.Errorf("Check() = %v on time, want 0", n)
+	}
+
+	if n := m.Check(last.Add(datetime.Milliseconds(1200))); n != 1 {
+		t.Errorf("Check() = %v with phi over 1, want 1", n)
+	}
+
+	// Suspected peers are reported once
+	if n := m.Check(last.Add(datetime.Seconds(10))); n != 0 {
+		t.Errorf("Check() = %v for suspected peer, want 0", n)
+	}
+}
+
+func TestMonitorRun(t *testing.T) {
+	var once sync.Once
+	suspected := make(chan int)
+
+	m := failuredetector.NewMonitor(failuredetector.MonitorConfig[int]{
+		Detector: failuredetector.Config{
+			FirstInterval: datetime.Milliseconds(1),
+			MinStdDev:     datetime.Milliseconds(1),
+		},
+		OnTransition: func(tr failuredetector.Transition[int]) {
+			once.Do(func() { suspected <- tr.Peer })
+		},
+	})
+	m.Heartbeat(7, datetime.NowClock())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	go m.Run(ctx, datetime.Milliseconds(1))
+
+	select {
+	case peer := <-suspected:
+		if peer != 7 {
+			t.Errorf("suspected peer %v, want 7", peer)
+		}
+	case <-ctx.Done():
+		t.Fatalf("peer not suspected")
+	}
+}
+
+func TestMonitorRunManualClock(t *testing.T) {
+	clock := datetime.NewManualClock(start)
+	transitions := make(chan failuredetector.Transition[int], 1)
+
+	m := failuredetector.NewMonitor(failuredetector.MonitorConfig[int]{
+		Detector: failuredetector.Config{Clock: clock},
+		OnTransition: func(tr failuredetector.Transition[int]) {
+			transitions <- tr
+		},
+	})
+	m.Heartbeat(7, start)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	go func() {
+		m.Run(ctx, datetime.Seconds(1))
+		close(stopped)
+	}()
+
+	// Checks wait for the clock, not for real time
+	waitForWaiters(t, clock, 1)
+	time.Sleep(10 * time.Millisecond)
+	if clock.Waiters() != 1 || len(transitions) != 0 {
+		t.Fatalf("Run() checked before the clock was advanced")
+	}
+
+	clock.Advance(datetime.Seconds(30))
+	select {
+	case tr := <-transitions:
+		if tr.Peer != 7 || tr.At != start.Add(datetime.Seconds(30)) {
+			t.Errorf("Transition = %+v, want peer 7 at 30s", tr)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("peer not suspected")
+	}
+
+	// The next check is scheduled, then Run stops with ctx
+	waitForWaiters(t, clock, 1)
+	cancel()
+	<-stopped
+
+	if clock.Waiters() != 0 {
+		t.Errorf("Waiters() = %v after Run returned, want 0", clock.Waiters())
+	}
+}
+
+// waitForWaiters waits until n calls are scheduled on the clock
+func waitForWaiters(t *testing.T, clock *datetime.ManualClock, n int) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for clock.Waiters() != n {
+		if time.Now().After(deadline) {
+			t.Fatalf("Waiters() = %v, want %v", clock.Waiters(), n)
+		}
+		runtime.Gosched()
+	}
+}
@@ -0,0 +1,168 @@
+package datetime
+
+/*
+Command line flags.
+
+*Duration and *Time implement flag.Value, so they can be used with flag.Var:
+
+	timeout := datetime.Minutes(1)
+	flag.Var(&timeout, "timeout", "request timeout")
+
+Durations accept the syntax of time.ParseDuration with two more units, "d" for 24
+hours and "w" for 7 days, like "1w2d" or "1.5d". Times accept RFC 3339, date and
+time without an offset (in UTC), a date alone and RFC 1123, which is the output of
+Time.String.
+*/
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+const hoursPerWeek = daysPerWeek * hoursPerDay
+
+/*
+ParseExtendedDuration is like ParseDuration, but also accepts "d" for days of 24
+hours and "w" for weeks of 7 days.
+
+Errors are either ErrInvalidSyntax or ErrOutOfRange.
+*/
+func ParseExtendedDuration(s string) (Duration, error) {
+	orig := s
+
+	sign := Duration(1)
+	if s != "" && (s[0] == '-' || s[0] == '+') {
+		if s[0] == '-' {
+			sign = -1
+		}
+		s = s[1:]
+	}
+
+	if s == "0" {
+		return 0, nil
+	}
+
+	if s == "" {
+		return 0, fmt.Errorf("%w: duration %q", ErrInvalidSyntax, orig)
+	}
+
+	var total Duration
+	for s != "" {
+		// Each term is a decimal number followed by a unit
+		i := strings.IndexFunc(s, isDurationUnitRune)
+		if i <= 0 {
+			return 0, fmt.Errorf("%w: duration %q", ErrInvalidSyntax, orig)
+		}
+
+		number, rest := s[:i], s[i:]
+		j := strings.IndexFunc(rest, isDurationNumberRune)
+		if j < 0 {
+			j = len(rest)
+		}
+
+		term, err := parseDurationTerm(number, rest[:j])
+		if err != nil {
+			return 0, fmt.Errorf("%w: duration %q", err, orig)
+		}
+
+		if total > math.MaxInt64-term {
+			return 0, fmt.Errorf("%w: duration %q", ErrOutOfRange, orig)
+		}
+
+		total += term
+		s = rest[j:]
+	}
+
+	return sign * total, nil
+}
+
+// isDurationNumberRune reports whether r is part of a decimal number
+func isDurationNumberRune(r rune) bool {
+	return r == '.' || r >= '0' && r <= '9'
+}
+
+// isDurationUnitRune reports whether r is part of a unit
+func isDurationUnitRune(r rune) bool {
+	return !isDurationNumberRune(r)
+}
+
+// parseDurationTerm parses a single number with a unit
+func parseDurationTerm(number, unit string) (Duration, error) {
+	hours := int64(0)
+	switch unit {
+	case "d":
+		hours = hoursPerDay
+	case "w":
+		hours = hoursPerWeek
+	default:
+		d, err := time.ParseDuration(number + unit)
+		if err != nil {
+			return 0, ErrInvalidSyntax
+		}
+		return Duration(d), nil
+	}
+
+	d, err := time.ParseDuration(number + "h")
+	if err != nil {
+		return 0, ErrInvalidSyntax
+	}
+
+	if int64(d) > math.MaxInt64/hours {
+		return 0, ErrOutOfRange
+	}
+
+	return Duration(int64(d) * hours), nil
+}
+
+// Set implements flag.Value, s is parsed with ParseExtendedDuration
+func (d *Duration) Set(s string) error {
+	parsed, err := ParseExtendedDuration(s)
+	if err != nil {
+		return err
+	}
+
+	*d = parsed
+	return nil
+}
+
+// timeFlagLayouts returns the layouts accepted by Time.Set, in order
+func timeFlagLayouts() []PrintFormat {
+	return []PrintFormat{
+		RFC3339Nano,
+		"2006-01-02T15:04:05.999999999",
+		"2006-01-02 15:04:05.999999999",
+		DateOnly,
+		RFC1123Z,
+		RFC1123,
+	}
+}
+
+/*
+ParseLayouts parses s with the first of the Go layouts that matches. The time is
+converted to UTC, layouts without an offset are in UTC.
+
+An error wrapping ErrInvalidSyntax is returned when no layout matches.
+*/
+func ParseLayouts(s string, layouts ...PrintFormat) (Time, error) {
+	for _, layout := range layouts {
+		if t, err := time.Parse(string(layout), s); err == nil {
+			return wallFromTime(t), nil
+		}
+	}
+
+	return Time{}, fmt.Errorf("%w: no layout matches time %q", ErrInvalidSyntax, s)
+}
+
+// Set implements flag.Value, accepting RFC 3339, "2006-01-02 15:04:05", a date or
+// RFC 1123
+func (t *Time) Set(s string) error {
+	parsed, err := ParseLayouts(s, timeFlagLayouts()...)
+	if err != nil {
+		return err
+	}
+
+	*t = parsed
+	return nil
+}
@@ -0,0 +1,146 @@
+package datetime_test
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/ram-nad/go-utils/datetime"
+)
+
+func TestParseExtendedDuration(t *testing.T) {
+	tests := []struct {
+		s        string
+		expected datetime.Duration
+	}{
+		{"0", 0},
+		{"1h30m", datetime.Minutes(90)},
+		{"1d", datetime.Hours(24)},
+		{"1.5d", datetime.Hours(36)},
+		{"2w", datetime.Hours(24 * 14)},
+		{
+			"1w2d3h4m5s",
+			datetime.Hours(24*9+3) + datetime.Minutes(4) + datetime.Seconds(5),
+		},
+		{"-1d12h", -datetime.Hours(36)},
+		{"+.5w", datetime.Hours(84)},
+		{"1µs1us1ns", datetime.Nanoseconds(2001)},
+		{"15250w", datetime.Hours(24 * 7 * 15250)},
+	}
+
+	for _, tc := range tests {
+		got, err := datetime.ParseExtendedDuration(tc.s)
+		if err != nil || got != tc.expected {
+			t.Errorf(
+				"ParseExtendedDuration(%q) = %v, %v, want %v",
+				tc.s,
+				got,
+				err,
+				tc.expected,
+			)
+		}
+
+		// Standard syntax is parsed like time.ParseDuration
+		if expected, err := time.ParseDuration(tc.s); err == nil &&
+			datetime.Duration(expected) != got {
+			t.Errorf("ParseExtendedDuration(%q) = %v, want %v", tc.s, got, expected)
+		}
+	}
+
+	invalid := []struct {
+		s   string
+		err error
+	}{
+		{"", datetime.ErrInvalidSyntax},
+		{"-", datetime.ErrInvalidSyntax},
+		{"5", datetime.ErrInvalidSyntax},
+		{"d", datetime.ErrInvalidSyntax},
+		{"1y", datetime.ErrInvalidSyntax},
+		{"1d-2h", datetime.ErrInvalidSyntax},
+		{"1 d", datetime.ErrInvalidSyntax},
+		{"..1d", datetime.ErrInvalidSyntax},
+		{"15251w", datetime.ErrOutOfRange},
+		{"15250w1w", datetime.ErrOutOfRange},
+	}
+
+	for _, tc := range invalid {
+		if _, err := datetime.ParseExtendedDuration(tc.s); !errors.Is(err, tc.err) {
+			t.Errorf("ParseExtendedDuration(%q) = %v, want %v", tc.s, err, tc.err)
+		}
+	}
+}
+
+func TestParseLayouts(t *testing.T) {
+	expected := datetime.Date(2021, 8, 9, 16, 7, 3, 0)
+
+	tests := []string{
+		"2021-08-09T16:07:03Z",
+		"2021-08-09T21:37:03+05:30",
+		"2021-08-09T16:07:03",
+		"2021-08-09 16:07:03",
+		"Mon, 09 Aug 2021 16:07:03 UTC",
+		"Mon, 09 Aug 2021 12:07:03 -0400",
+	}
+
+	for _, s := range tests {
+		var got datetime.Time
+		if err := got.Set(s); err != nil || !got.Equal(expected) {
+			t.Errorf("Set(%q) = %v, %v, want %v", s, got, err, expected)
+		}
+	}
+
+	var got datetime.Time
+	midnight := expected.StartOf(datetime.UnitDay)
+	if err := got.Set("2021-08-09"); err != nil || !got.Equal(midnight) {
+		t.Errorf("Set(2021-08-09) = %v, %v", got, err)
+	}
+
+	if err := got.Set("09/08/2021"); !errors.Is(err, datetime.ErrInvalidSyntax) {
+		t.Errorf("Set(09/08/2021) = %v, want ErrInvalidSyntax", err)
+	}
+
+	custom, err := datetime.ParseLayouts("09/08/2021", "01/02/2006", "02/01/2006")
+	if err != nil || !custom.Equal(datetime.Date(2021, 9, 8, 0, 0, 0, 0)) {
+		t.Errorf("ParseLayouts(09/08/2021) = %v, %v", custom, err)
+	}
+}
+
+func TestFlagVar(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	timeout := datetime.Seconds(30)
+	var cutoff datetime.Time
+	fs.Var(&timeout, "timeout", "request timeout")
+	fs.Var(&cutoff, "cutoff", "ignore events before")
+
+	err := fs.Parse([]string{"-timeout", "1d12h", "-cutoff", "2021-08-09T16:07:03Z"})
+	if err != nil {
+		t.Fatalf("Parse() = %v", err)
+	}
+
+	expected := datetime.Date(2021, 8, 9, 16, 7, 3, 0)
+	if timeout != datetime.Hours(36) || !cutoff.Equal(expected) {
+		t.Errorf("Parse() set timeout = %v, cutoff = %v", timeout, cutoff)
+	}
+
+	if err := fs.Parse([]string{"-timeout", "soon"}); err == nil {
+		t.Errorf("Expected error for invalid duration")
+	}
+
+	// Default values are printed with String and can be parsed back
+	var parsed datetime.Time
+	if err := parsed.Set(cutoff.String()); err != nil || !parsed.Equal(cutoff) {
+		t.Errorf("Set(%q) = %v, %v, want %v", cutoff.String(), parsed, err, cutoff)
+	}
+}
+
+func ExampleParseExtendedDuration() {
+	d, _ := datetime.ParseExtendedDuration("1w2d")
+	_, _ = fmt.Println(d, d.Hours())
+	// Output:
+	// 216h0m0s 216
+}
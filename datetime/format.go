@@ -0,0 +1,141 @@
+package datetime
+
+/*
+Hand written encoder for RFC 3339 formats.
+
+time.Time.AppendFormat interprets the layout on every call, which dominates the cost
+of formatting timestamps in hot paths. Since Time is always in UTC, RFC 3339 output has
+a fixed shape and can be written directly from the Unix time.
+
+Output is byte-identical to time.Time.Format. Years outside [0, 9999] fall back to
+time.Time.AppendFormat.
+*/
+
+import "time"
+
+const (
+	maxFracDigits = 9
+	// Maximum length of RFC 3339 output with nanoseconds
+	maxRFC3339Len = len("2006-01-02T15:04:05.999999999Z")
+)
+
+// put2 writes v as two decimal digits at b[0:2]
+//
+//nolint:mnd // decimal digits
+func put2(b []byte, v int) {
+	_ = b[1] // Bounds check hint to the compiler
+	b[0] = byte('0' + v/10)
+	b[1] = byte('0' + v%10)
+}
+
+/*
+appendRFC3339 appends t in RFC 3339 format.
+
+fracDigits is the number of digits for fractional seconds, trailing zeros are removed
+when trim is set (and the fraction is dropped if it is zero).
+*/
+//nolint:mnd // field offsets of RFC 3339
+func appendRFC3339(dst []byte, t Time, fracDigits int, trim bool) []byte {
+	sec := t.Unix()
+	days := floorDiv(sec, secondsPerDay)
+	secOfDay := int(sec - days*secondsPerDay)
+	year, month, day := civilFromDays(days)
+
+	if year < 0 || year > 9999 {
+		return time.Time(t).AppendFormat(dst, rfc3339Layout(fracDigits, trim))
+	}
+
+	var buf [maxRFC3339Len]byte
+
+	// yyyy-mm-ddThh:mm:ss
+	put2(buf[0:], int(year)/100)
+	put2(buf[2:], int(year)%100)
+	buf[4] = '-'
+	put2(buf[5:], month)
+	buf[7] = '-'
+	put2(buf[8:], day)
+	buf[10] = 'T'
+	put2(buf[11:], secOfDay/secondsPerHour)
+	buf[13] = ':'
+	put2(buf[14:], secOfDay%secondsPerHour/secondsPerMinute)
+	buf[16] = ':'
+	put2(buf[17:], secOfDay%secondsPerMinute)
+	n := 19
+
+	if fracDigits > 0 {
+		n = putFraction(buf[n:], t.Nanosecond(), fracDigits, trim) + n
+	}
+
+	buf[n] = 'Z'
+
+	return append(dst, buf[:n+1]...)
+}
+
+/*
+putFraction writes '.' followed by first digits of nsec to b and returns number of
+bytes written. With trim, trailing zeros are removed and nothing is written for zero.
+*/
+//nolint:mnd // decimal digits
+func putFraction(b []byte, nsec int, digits int, trim bool) int {
+	_ = b[maxFracDigits] // Bounds check hint to the compiler
+
+	for i := digits; i < maxFracDigits; i++ {
+		nsec /= 10
+	}
+
+	if trim {
+		for digits > 0 && nsec%10 == 0 {
+			nsec /= 10
+			digits--
+		}
+
+		if digits == 0 {
+			return 0
+		}
+	}
+
+	b[0] = '.'
+	for i := digits; i > 0; i-- {
+		b[i] = byte('0' + nsec%10)
+		nsec /= 10
+	}
+
+	return digits + 1
+}
+
+// rfc3339Layout returns the Go layout handled by appendRFC3339 with the given options
+func rfc3339Layout(fracDigits int, trim bool) string {
+	switch {
+	case fracDigits == 0:
+		return time.RFC3339
+	case trim:
+		return time.RFC3339Nano
+	case fracDigits == 3: //nolint:mnd // milliseconds
+		return string(RFC3339Milli)
+	default:
+		return string(RFC3339Micro)
+	}
+}
+
+/*
+AppendFormat is like Format but appends the textual representation to dst and
+returns the extended buffer.
+
+RFC3339, RFC3339Milli, RFC3339Micro and RFC3339Nano use a specialized encoder and
+don't allocate when dst has enough capacity.
+*/
+//nolint:mnd // number of fractional digits
+func (t Time) AppendFormat(dst []byte, format PrintFormat) []byte {
+	switch format {
+	case RFC3339:
+		return appendRFC3339(dst, t, 0, false)
+	case RFC3339Milli:
+		return appendRFC3339(dst, t, 3, false)
+	case RFC3339Micro:
+		return appendRFC3339(dst, t, 6, false)
+	case RFC3339Nano:
+		return appendRFC3339(dst, t, maxFracDigits, true)
+	default:
+		return time.Time(t).AppendFormat(dst, string(format))
+	}
+}
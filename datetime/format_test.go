@@ -0,0 +1,199 @@
+package datetime_test
+
+import (
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"github.com/ram-nad/go-utils/datetime"
+)
+
+func rfc3339Formats() []datetime.PrintFormat {
+	return []datetime.PrintFormat{
+		datetime.RFC3339,
+		datetime.RFC3339Milli,
+		datetime.RFC3339Micro,
+		datetime.RFC3339Nano,
+	}
+}
+
+func checkFormatMatchesTime(t *testing.T, dt datetime.Time) {
+	t.Helper()
+
+	for _, format := range rfc3339Formats() {
+		expected := time.Time(dt).Format(string(format))
+
+		if got := string(dt.AppendFormat(nil, format)); got != expected {
+			t.Errorf("AppendFormat(%q) = %q, want %q", format, got, expected)
+		}
+
+		if got := dt.Format(format); got != expected {
+			t.Errorf("Format(%q) = %q, want %q", format, got, expected)
+		}
+	}
+}
+
+func TestAppendFormatRFC3339(t *testing.T) {
+	tests := []struct {
+		dt       datetime.Time
+		format   datetime.PrintFormat
+		expected string
+	}{
+		{
+			datetime.Date(2021, 1, 1, 0, 0, 0, 0),
+			datetime.RFC3339,
+			"2021-01-01T00:00:00Z",
+		},
+		{
+			datetime.Date(2021, 1, 1, 0, 0, 1, 23),
+			datetime.RFC3339Nano,
+			"2021-01-01T00:00:01.000000023Z",
+		},
+		{
+			datetime.Date(2021, 1, 1, 0, 0, 1, 120_000_000),
+			datetime.RFC3339Nano,
+			"2021-01-01T00:00:01.12Z",
+		},
+		{
+			datetime.Date(2021, 1, 1, 0, 0, 1, 0),
+			datetime.RFC3339Nano,
+			"2021-01-01T00:00:01Z",
+		},
+		{
+			datetime.Date(2024, 2, 29, 23, 59, 59, 999_999_999),
+			datetime.RFC3339Milli,
+			"2024-02-29T23:59:59.999Z",
+		},
+		{
+			datetime.Date(1969, 12, 31, 23, 59, 59, 1000),
+			datetime.RFC3339Micro,
+			"1969-12-31T23:59:59.000001Z",
+		},
+		{
+			datetime.Date(2000, 3, 1, 12, 0, 0, 0),
+			datetime.RFC3339Milli,
+			"2000-03-01T12:00:00.000Z",
+		},
+	}
+
+	for _, tc := range tests {
+		if got := string(tc.dt.AppendFormat(nil, tc.format)); got != tc.expected {
+			t.Errorf("AppendFormat(%q) = %q, want %q", tc.format, got, tc.expected)
+		}
+	}
+
+	prefix := []byte("ts=")
+	dt := datetime.Date(2021, 1, 1, 0, 0, 0, 0)
+	got := string(dt.AppendFormat(prefix, datetime.RFC3339))
+	if got != "ts=2021-01-01T00:00:00Z" {
+		t.Errorf("Expected AppendFormat to append to dst, got %q", got)
+	}
+}
+
+func TestAppendFormatMatchesTime(t *testing.T) {
+	t.Run("Boundaries", func(t *testing.T) {
+		for _, dt := range []datetime.Time{
+			datetime.Unix(0, 0),
+			datetime.Unix(-1, 999_999_999),
+			datetime.Date(0, 1, 1, 0, 0, 0, 0),
+			datetime.Date(-1, 12, 31, 23, 59, 59, 0),
+			datetime.Date(9999, 12, 31, 23, 59, 59, 999_999_999),
+			datetime.Date(10000, 1, 1, 0, 0, 0, 1),
+			datetime.Date(1600, 2, 29, 0, 0, 0, 0),
+			datetime.Date(1900, 3, 1, 0, 0, 0, 0),
+			datetime.Date(2100, 2, 28, 0, 0, 0, 0),
+		} {
+			checkFormatMatchesTime(t, dt)
+		}
+	})
+
+	t.Run("Random", func(t *testing.T) {
+		rng := rand.New(rand.NewPCG(1, 2))
+		minSec := datetime.Date(-100, 1, 1, 0, 0, 0, 0).Unix()
+		maxSec := datetime.Date(10100, 1, 1, 0, 0, 0, 0).Unix()
+
+		for range 100_000 {
+			sec := minSec + rng.Int64N(maxSec-minSec)
+			nsec := rng.Int64N(int64(time.Second))
+			if rng.IntN(2) == 0 {
+				nsec = nsec / 1000 * 1000
+			}
+			checkFormatMatchesTime(t, datetime.Unix(sec, nsec))
+		}
+	})
+}
+
+func TestAppendFormatOtherLayouts(t *testing.T) {
+	dt := datetime.Date(2021, 1, 2, 3, 4, 5, 0)
+
+	for _, format := range []datetime.PrintFormat{
+		datetime.RFC1123, datetime.RFC822Z, datetime.DateOnly, datetime.TimeOnly,
+	} {
+		expected := time.Time(dt).Format(string(format))
+		if got := string(dt.AppendFormat(nil, format)); got != expected {
+			t.Errorf("AppendFormat(%q) = %q, want %q", format, got, expected)
+		}
+	}
+}
+
+func TestAppendFormatAllocations(t *testing.T) {
+	dt := datetime.Date(2021, 1, 2, 3, 4, 5, 6)
+	buf := make([]byte, 0, 64)
+
+	for _, format := range rfc3339Formats() {
+		allocs := testing.AllocsPerRun(100, func() {
+			buf = dt.AppendFormat(buf[:0], format)
+		})
+
+		if allocs != 0 {
+			t.Errorf("AppendFormat(%q) allocated %v times, want 0", format, allocs)
+		}
+	}
+}
+
+func BenchmarkFormatRFC3339(b *testing.B) {
+	dt := datetime.Date(2021, 1, 2, 3, 4, 5, 6)
+	for b.Loop() {
+		_ = dt.Format(datetime.RFC3339)
+	}
+}
+
+func BenchmarkAppendFormatRFC3339(b *testing.B) {
+	dt := datetime.Date(2021, 1, 2, 3, 4, 5, 6)
+	buf := make([]byte, 0, 64)
+	for b.Loop() {
+		buf = dt.AppendFormat(buf[:0], datetime.RFC3339)
+	}
+}
+
+func BenchmarkAppendFormatRFC3339Nano(b *testing.B) {
+	dt := datetime.Date(2021, 1, 2, 3, 4, 5, 678_900_000)
+	buf := make([]byte, 0, 64)
+	for b.Loop() {
+		buf = dt.AppendFormat(buf[:0], datetime.RFC3339Nano)
+	}
+}
+
+func BenchmarkAppendFormatRFC3339Milli(b *testing.B) {
+	dt := datetime.Date(2021, 1, 2, 3, 4, 5, 678_900_000)
+	buf := make([]byte, 0, 64)
+	for b.Loop() {
+		buf = dt.AppendFormat(buf[:0], datetime.RFC3339Milli)
+	}
+}
+
+func BenchmarkTimeAppendFormatRFC3339(b *testing.B) {
+	tm := time.Date(2021, 1, 2, 3, 4, 5, 6, time.UTC)
+	buf := make([]byte, 0, 64)
+	for b.Loop() {
+		buf = tm.AppendFormat(buf[:0], time.RFC3339)
+	}
+}
+
+func BenchmarkTimeAppendFormatRFC3339Nano(b *testing.B) {
+	tm := time.Date(2021, 1, 2, 3, 4, 5, 678_900_000, time.UTC)
+	buf := make([]byte, 0, 64)
+	for b.Loop() {
+		buf = tm.AppendFormat(buf[:0], time.RFC3339Nano)
+	}
+}
@@ -0,0 +1,246 @@
+package datetime
+
+/*
+HTTP dates as defined in RFC 9110, section 5.6.7.
+
+Senders must use IMF-fixdate, recipients must also accept two obsolete formats:
+
+	IMF-fixdate  Sun, 06 Nov 1994 08:49:37 GMT
+	RFC 850      Sunday, 06-Nov-94 08:49:37 GMT
+	asctime      Sun Nov  6 08:49:37 1994
+
+Names are case-sensitive. The weekday must be a valid name, but it isn't checked
+against the date. RFC 850 dates with a two-digit year that is more than 50 years in
+the future are in the past century.
+
+Retry-After (section 10.2.3) is either an HTTP date or a number of seconds.
+*/
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RetryAfter is the value of a Retry-After header, either a delay or a date
+type RetryAfter struct {
+	// Delay is the number of seconds to wait, when IsDate is false
+	Delay Duration
+	// Date is the time after which to retry, when IsDate is true
+	Date   Time
+	IsDate bool
+}
+
+const (
+	httpDateLen   = len("Mon, 02 Jan 2006 15:04:05 GMT")
+	asctimeLen    = len("Mon Jan _2 15:04:05 2006")
+	rfc850TailLen = len("02-Jan-06 15:04:05 GMT")
+	httpClockLen  = len("15:04:05")
+	// RFC 850 years more than this in the future are in the past
+	rfc850FutureYears = 50
+)
+
+// FormatHTTPDate returns the time in IMF-fixdate format
+func FormatHTTPDate(t Time) string {
+	var buf [httpDateLen]byte
+	return string(AppendHTTPDate(buf[:0], t))
+}
+
+/*
+AppendHTTPDate appends the time in IMF-fixdate format to dst.
+
+Years outside [0, 9999] can't be represented in HTTP, they are written like Format
+does.
+*/
+//nolint:mnd // field offsets of IMF-fixdate
+func AppendHTTPDate(dst []byte, t Time) []byte {
+	year := t.Year()
+	if year < 0 || year > 9999 {
+		return time.Time(t).AppendFormat(dst, time.RFC1123)
+	}
+
+	var buf [httpDateLen]byte
+
+	// Mon, 02 Jan 2006 15:04:05 GMT
+	copy(buf[0:], t.Weekday().String()[:nameAbbrevLen])
+	buf[3], buf[4] = ',', ' '
+	put2(buf[5:], t.Day())
+	buf[7] = ' '
+	copy(buf[8:], t.Month().String()[:nameAbbrevLen])
+	buf[11] = ' '
+	put2(buf[12:], year/100)
+	put2(buf[14:], year%100)
+	buf[16] = ' '
+	put2(buf[17:], t.Hour())
+	buf[19] = ':'
+	put2(buf[20:], t.Minute())
+	buf[22] = ':'
+	put2(buf[23:], t.Second())
+	copy(buf[25:], " GMT")
+
+	return append(dst, buf[:]...)
+}
+
+/*
+ParseHTTPDate parses a date in IMF-fixdate, RFC 850 or asctime format.
+
+Errors are either ErrInvalidSyntax or ErrOutOfRange.
+*/
+func ParseHTTPDate(s string) (Time, error) {
+	return parseHTTPDate(s, Now)
+}
+
+// parseHTTPDate parses an HTTP date, two-digit years are resolved relative to now
+func parseHTTPDate(s string, now func() Time) (Time, error) {
+	weekday, day, month, year, clock, ok := splitHTTPDate(s)
+	if !ok || !isWeekdayName(weekday) {
+		return Time{}, ErrInvalidSyntax
+	}
+
+	m := monthAbbrev(month)
+	d, okDay := parseDigits(strings.TrimPrefix(day, " "))
+	y, okYear := parseDigits(year)
+	if m == 0 || !okDay || !okYear {
+		return Time{}, ErrInvalidSyntax
+	}
+
+	if len(year) == 2 { //nolint:mnd // two-digit year of RFC 850
+		y = resolveRFC850Year(y, now().Year())
+	}
+
+	secOfDay, err := parseHTTPClock(clock)
+	if err != nil {
+		return Time{}, err
+	}
+
+	if d < 1 || d > daysIn(Month(m), int64(y)) {
+		return Time{}, ErrOutOfRange
+	}
+
+	return Unix(daysFromCivil(int64(y), m, d)*secondsPerDay+int64(secOfDay), 0), nil
+}
+
+// splitHTTPDate splits s into fields of one of the three HTTP date formats
+//
+//nolint:mnd // field offsets of HTTP date formats
+func splitHTTPDate(s string) (weekday, day, month, year, clock string, ok bool) {
+	switch {
+	case len(s) == httpDateLen && s[3] == ',':
+		// Sun, 06 Nov 1994 08:49:37 GMT
+		ok = s[4] == ' ' && s[7] == ' ' && s[11] == ' ' && s[16] == ' ' &&
+			s[25:] == " GMT"
+		return s[:3], s[5:7], s[8:11], s[12:16], s[17:25], ok
+	case len(s) == asctimeLen && s[3] == ' ':
+		// Sun Nov  6 08:49:37 1994
+		ok = s[7] == ' ' && s[10] == ' ' && s[19] == ' '
+		return s[:3], s[8:10], s[4:7], s[20:], s[11:19], ok
+	default:
+		// Sunday, 06-Nov-94 08:49:37 GMT
+		weekday, tail, found := strings.Cut(s, ", ")
+		if !found || len(tail) != rfc850TailLen || len(weekday) <= nameAbbrevLen {
+			return "", "", "", "", "", false
+		}
+
+		ok = tail[2] == '-' && tail[6] == '-' && tail[9] == ' ' && tail[18:] == " GMT"
+		return weekday, tail[0:2], tail[3:6], tail[7:9], tail[10:18], ok
+	}
+}
+
+// isWeekdayName reports whether s is a weekday name or its abbreviation
+func isWeekdayName(s string) bool {
+	for d := range Weekday(daysPerWeek) {
+		name := d.String()
+		if s == name || s == name[:nameAbbrevLen] {
+			return true
+		}
+	}
+	return false
+}
+
+// monthAbbrev returns the month for an abbreviated name like "Jan", or zero
+func monthAbbrev(s string) int {
+	for m := time.January; m <= time.December; m++ {
+		if s == m.String()[:nameAbbrevLen] {
+			return int(m)
+		}
+	}
+	return 0
+}
+
+// parseHTTPClock parses "15:04:05" and returns seconds since midnight
+//
+//nolint:mnd // field offsets and ranges of the clock
+func parseHTTPClock(s string) (int, error) {
+	if len(s) != httpClockLen || s[2] != ':' || s[5] != ':' {
+		return 0, ErrInvalidSyntax
+	}
+
+	hour, ok1 := parseDigits(s[0:2])
+	minute, ok2 := parseDigits(s[3:5])
+	second, ok3 := parseDigits(s[6:8])
+	if !ok1 || !ok2 || !ok3 {
+		return 0, ErrInvalidSyntax
+	}
+
+	if hour > 23 || minute > 59 || second > 59 {
+		return 0, ErrOutOfRange
+	}
+
+	return hour*secondsPerHour + minute*secondsPerMinute + second, nil
+}
+
+// resolveRFC850Year returns the year with last two digits yy as RFC 9110 requires
+func resolveRFC850Year(yy, currentYear int) int {
+	year := currentYear - currentYear%yearsPerCentury + yy
+	if year > currentYear+rfc850FutureYears {
+		year -= yearsPerCentury
+	}
+	return year
+}
+
+/*
+ParseRetryAfter parses the value of a Retry-After header, which is either an HTTP
+date or a non-negative number of seconds.
+
+Errors are either ErrInvalidSyntax or ErrOutOfRange.
+*/
+func ParseRetryAfter(s string) (RetryAfter, error) {
+	s = strings.Trim(s, " \t")
+
+	if s != "" && isDigit(s[0]) {
+		seconds, err := strconv.ParseInt(s, 10, 64)
+		switch {
+		case err == nil && seconds <= Duration(math.MaxInt64).Seconds():
+			return RetryAfter{Delay: Seconds(seconds)}, nil
+		case err == nil || strings.Trim(s, "0123456789") == "":
+			return RetryAfter{}, fmt.Errorf("%w: delay %q", ErrOutOfRange, s)
+		default:
+			return RetryAfter{}, fmt.Errorf("%w: delay %q", ErrInvalidSyntax, s)
+		}
+	}
+
+	date, err := ParseHTTPDate(s)
+	if err != nil {
+		return RetryAfter{}, fmt.Errorf("%w: date %q", err, s)
+	}
+
+	return RetryAfter{Date: date, IsDate: true}, nil
+}
+
+// Until returns how long to wait from now, zero when the date has passed
+func (r RetryAfter) Until(now Time) Duration {
+	if !r.IsDate {
+		return r.Delay
+	}
+	return max(r.Date.Sub(now), 0)
+}
+
+// Time returns the time after which to retry, a delay is counted from now
+func (r RetryAfter) Time(now Time) Time {
+	if r.IsDate {
+		return r.Date
+	}
+	return now.Add(r.Delay)
+}
@@ -0,0 +1,170 @@
+package datetime_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/ram-nad/go-utils/datetime"
+)
+
+func TestParseHTTPDate(t *testing.T) {
+	expected := datetime.Date(1994, 11, 6, 8, 49, 37, 0)
+
+	tests := []struct {
+		s        string
+		expected datetime.Time
+	}{
+		{"Sun, 06 Nov 1994 08:49:37 GMT", expected},
+		{"Sunday, 06-Nov-94 08:49:37 GMT", expected},
+		{"Sun Nov  6 08:49:37 1994", expected},
+		{"Sun Nov 16 08:49:37 1994", expected.AddDate(0, 0, 10)},
+		// Weekday isn't checked against the date
+		{"Fri, 06 Nov 1994 08:49:37 GMT", expected},
+		{"Thu, 29 Feb 2024 23:59:59 GMT", datetime.Date(2024, 2, 29, 23, 59, 59, 0)},
+		{"Monday, 01-Jan-30 00:00:00 GMT", datetime.Date(2030, 1, 1, 0, 0, 0, 0)},
+		{"Tuesday, 01-Jan-80 00:00:00 GMT", datetime.Date(1980, 1, 1, 0, 0, 0, 0)},
+	}
+
+	for _, tc := range tests {
+		got, err := datetime.ParseHTTPDate(tc.s)
+		if err != nil || !got.Equal(tc.expected) {
+			t.Errorf("ParseHTTPDate(%q) = %v, %v, want %v", tc.s, got, err, tc.expected)
+		}
+	}
+}
+
+func TestParseHTTPDateErrors(t *testing.T) {
+	tests := []struct {
+		s   string
+		err error
+	}{
+		{"", datetime.ErrInvalidSyntax},
+		{"Sun, 06 Nov 1994 08:49:37 UTC", datetime.ErrInvalidSyntax},
+		{"Sun, 06 Nov 1994 08:49:37 +0000", datetime.ErrInvalidSyntax},
+		{"sun, 06 Nov 1994 08:49:37 GMT", datetime.ErrInvalidSyntax},
+		{"Sun, 06 nov 1994 08:49:37 GMT", datetime.ErrInvalidSyntax},
+		{"Sun, 6 Nov 1994 08:49:37 GMT", datetime.ErrInvalidSyntax},
+		{"Sun, 06 Nov 1994 08:49 GMT", datetime.ErrInvalidSyntax},
+		{"Sun, 06-Nov-94 08:49:37 GMT", datetime.ErrInvalidSyntax},
+		{"Sunday, 06 Nov 1994 08:49:37 GMT", datetime.ErrInvalidSyntax},
+		{"Sun Nov 6 08:49:37 1994", datetime.ErrInvalidSyntax},
+		{"1994-11-06T08:49:37Z", datetime.ErrInvalidSyntax},
+		{"Sun, 31 Nov 1994 08:49:37 GMT", datetime.ErrOutOfRange},
+		{"Sun, 29 Feb 2023 08:49:37 GMT", datetime.ErrOutOfRange},
+		{"Sun, 06 Nov 1994 24:00:00 GMT", datetime.ErrOutOfRange},
+		{"Sun, 06 Nov 1994 08:49:60 GMT", datetime.ErrOutOfRange},
+	}
+
+	for _, tc := range tests {
+		if _, err := datetime.ParseHTTPDate(tc.s); !errors.Is(err, tc.err) {
+			t.Errorf("ParseHTTPDate(%q) = %v, want %v", tc.s, err, tc.err)
+		}
+	}
+}
+
+func TestFormatHTTPDate(t *testing.T) {
+	start := datetime.Date(1899, 12, 25, 1, 2, 3, 999_999_999)
+
+	for i := range 2000 {
+		dt := start.Add(datetime.Hours(int64(i) * 1009))
+		got := datetime.FormatHTTPDate(dt)
+
+		// net/http uses the same format
+		if expected := dt.Format(http.TimeFormat); got != expected {
+			t.Fatalf("FormatHTTPDate(%v) = %q, want %q", dt, got, expected)
+		}
+
+		parsed, err := datetime.ParseHTTPDate(got)
+		if err != nil || !parsed.Equal(dt.Truncate(datetime.Seconds(1))) {
+			t.Fatalf("ParseHTTPDate(%q) = %v, %v, want %v", got, parsed, err, dt)
+		}
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := datetime.Date(2021, 8, 9, 16, 7, 3, 0)
+
+	tests := []struct {
+		s      string
+		until  datetime.Duration
+		isDate bool
+	}{
+		{"120", datetime.Minutes(2), false},
+		{" 0\t", 0, false},
+		{"Mon, 09 Aug 2021 16:08:03 GMT", datetime.Minutes(1), true},
+		// Dates in the past mean retry now
+		{"Mon, 09 Aug 2021 16:00:00 GMT", 0, true},
+	}
+
+	for _, tc := range tests {
+		got, err := datetime.ParseRetryAfter(tc.s)
+		if err != nil || got.IsDate != tc.isDate || got.Until(now) != tc.until {
+			t.Errorf(
+				"ParseRetryAfter(%q) = %+v, %v, want %v after %v",
+				tc.s,
+				got,
+				err,
+				tc.until,
+				now,
+			)
+		}
+
+		if expected := now.Add(tc.until); got.Until(now) > 0 &&
+			!got.Time(now).Equal(expected) {
+			t.Errorf(
+				"ParseRetryAfter(%q).Time() = %v, want %v",
+				tc.s,
+				got.Time(now),
+				expected,
+			)
+		}
+	}
+
+	invalid := []struct {
+		s   string
+		err error
+	}{
+		{"", datetime.ErrInvalidSyntax},
+		{"-1", datetime.ErrInvalidSyntax},
+		{"1.5", datetime.ErrInvalidSyntax},
+		{"10s", datetime.ErrInvalidSyntax},
+		{"99999999999", datetime.ErrOutOfRange},
+		{"99999999999999999999", datetime.ErrOutOfRange},
+		{"Mon, 32 Aug 2021 16:08:03 GMT", datetime.ErrOutOfRange},
+	}
+
+	for _, tc := range invalid {
+		if _, err := datetime.ParseRetryAfter(tc.s); !errors.Is(err, tc.err) {
+			t.Errorf("ParseRetryAfter(%q) = %v, want %v", tc.s, err, tc.err)
+		}
+	}
+}
+
+func BenchmarkFormatHTTPDate(b *testing.B) {
+	dt := datetime.Date(2021, 8, 9, 16, 7, 3, 0)
+	buf := make([]byte, 0, 64)
+
+	for b.Loop() {
+		buf = datetime.AppendHTTPDate(buf[:0], dt)
+	}
+}
+
+func BenchmarkParseHTTPDate(b *testing.B) {
+	for b.Loop() {
+		_, _ = datetime.ParseHTTPDate("Mon, 09 Aug 2021 16:07:03 GMT")
+	}
+}
+
+func ExampleParseRetryAfter() {
+	now := datetime.Date(2021, 8, 9, 16, 7, 3, 0)
+
+	for _, header := range []string{"90", "Mon, 09 Aug 2021 16:10:00 GMT"} {
+		r, _ := datetime.ParseRetryAfter(header)
+		_, _ = fmt.Println(r.Until(now), datetime.FormatHTTPDate(r.Time(now)))
+	}
+	// Output:
+	// 1m30s Mon, 09 Aug 2021 16:08:33 GMT
+	// 2m57s Mon, 09 Aug 2021 16:10:00 GMT
+}
@@ -0,0 +1,100 @@
+package iotimeout
+
+/*
+Conn sets the read or write deadline of the connection before every operation, to the
+earlier of now plus Idle and Deadline. Deadlines set on the connection directly are
+overwritten. Network deadlines follow the system clock, so Config.Clock must be nil or
+datetime.SystemClock. After an idle timeout the Conn may still be used, the error
+reports Temporary.
+
+Reads and writes are limited independently, a Read may run concurrently with a Write.
+*/
+
+import (
+	"errors"
+	"net"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"github.com/ram-nad/go-utils/datetime"
+)
+
+// Conn is a net.Conn with time limits, it is safe for concurrent use like net.Conn
+type Conn struct {
+	net.Conn
+	config  Config
+	start   datetime.ClockTime
+	read    atomic.Int64
+	written atomic.Int64
+}
+
+// NewConn wraps c with limits, the Elapsed time of errors counts from now. NewConn
+// panics if Config.Clock isn't nil or datetime.SystemClock
+func NewConn(c net.Conn, config Config) *Conn {
+	if _, ok := config.Clock.(datetime.SystemClock); !ok && config.Clock != nil {
+		panic("iotimeout: clock other than SystemClock for NewConn")
+	}
+
+	config.Clock = datetime.SystemClock{}
+
+	return &Conn{Conn: c, config: config, start: datetime.NowClock()}
+}
+
+// Read reads from the connection, or fails with a *TimeoutError
+func (c *Conn) Read(p []byte) (int, error) {
+	return c.do("read", &c.read, c.Conn.SetReadDeadline, func() (int, error) {
+		return c.Conn.Read(p)
+	})
+}
+
+// Write writes to the connection, or fails with a *TimeoutError
+func (c *Conn) Write(p []byte) (int, error) {
+	return c.do("write", &c.written, c.Conn.SetWriteDeadline, func() (int, error) {
+		return c.Conn.Write(p)
+	})
+}
+
+// BytesRead returns the number of bytes read
+func (c *Conn) BytesRead() int64 {
+	return c.read.Load()
+}
+
+// BytesWritten returns the number of bytes written
+func (c *Conn) BytesWritten() int64 {
+	return c.written.Load()
+}
+
+// do sets the deadline for an operation on op and calls f
+func (c *Conn) do(
+	op string,
+	bytes *atomic.Int64,
+	setDeadline func(time.Time) error,
+	f func() (int, error),
+) (int, error) {
+	now := datetime.NowClock()
+	g := guard{config: c.config, start: c.start, bytes: bytes.Load()}
+
+	deadline := time.Time{}
+	if wait, limited := g.budget(now); limited {
+		if wait <= 0 {
+			return 0, g.timeout(op, now)
+		}
+		deadline = time.Now().Add(time.Duration(wait))
+	}
+
+	if err := setDeadline(deadline); err != nil {
+		return 0, err
+	}
+
+	n, err := f()
+	g.bytes = bytes.Add(int64(n))
+
+	if errors.Is(err, os.ErrDeadlineExceeded) {
+		timeout := g.timeout(op, datetime.NowClock())
+		timeout.temporary = timeout.Err == ErrIdleTimeout
+		return n, timeout
+	}
+
+	return n, err
+}
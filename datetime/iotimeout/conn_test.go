@@ -0,0 +1,101 @@
+package iotimeout_test
+
+import (
+	"errors"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/ram-nad/go-utils/datetime"
+	"github.com/ram-nad/go-utils/datetime/iotimeout"
+)
+
+func TestConnIdleTimeout(t *testing.T) {
+	client, server := net.Pipe()
+	defer client.Close()
+	defer server.Close()
+
+	c := iotimeout.NewConn(server, iotimeout.Config{Idle: datetime.Milliseconds(20)})
+
+	go func() { _, _ = client.Write([]byte("ping")) }()
+
+	buf := make([]byte, 4)
+	if _, err := io.ReadFull(c, buf); err != nil {
+		t.Fatalf("Read() = %v", err)
+	}
+
+	_, err := c.Read(buf)
+	if !errors.Is(err, iotimeout.ErrIdleTimeout) {
+		t.Fatalf("Read() = %v, want %v", err, iotimeout.ErrIdleTimeout)
+	}
+
+	var timeout *iotimeout.TimeoutError
+	if !errors.As(err, &timeout) || timeout.Bytes != 4 ||
+		timeout.Elapsed < datetime.Milliseconds(20) {
+		t.Errorf("Read() = %v, want 4 bytes after at least 20ms", err)
+	}
+
+	if !timeout.Temporary() {
+		t.Errorf("Temporary() = false for an idle timeout of a Conn")
+	}
+
+	// Conn stays usable after an idle timeout
+	go func() { _, _ = client.Write([]byte("pong")) }()
+	if _, err := io.ReadFull(c, buf); err != nil || string(buf) != "pong" {
+		t.Errorf("Read() after timeout = %q, %v", buf, err)
+	}
+
+	if c.BytesRead() != 8 {
+		t.Errorf("BytesRead() = %v, want 8", c.BytesRead())
+	}
+}
+
+func TestConnDeadline(t *testing.T) {
+	client, server := net.Pipe()
+	defer client.Close()
+	defer server.Close()
+
+	c := iotimeout.NewConn(server, iotimeout.Config{
+		Idle:     datetime.Seconds(10),
+		Deadline: datetime.NowClock().Add(datetime.Milliseconds(20)),
+	})
+
+	// Nobody reads from client
+	_, err := c.Write([]byte("data"))
+	if !errors.Is(err, iotimeout.ErrDeadlineExceeded) {
+		t.Fatalf("Write() = %v, want %v", err, iotimeout.ErrDeadlineExceeded)
+	}
+
+	// Past the deadline operations fail right away
+	_, err = c.Read(make([]byte, 1))
+	if !errors.Is(err, iotimeout.ErrDeadlineExceeded) {
+		t.Errorf("Read() = %v, want %v", err, iotimeout.ErrDeadlineExceeded)
+	}
+
+	var timeout *iotimeout.TimeoutError
+	if !errors.As(err, &timeout) || timeout.Temporary() {
+		t.Errorf("Read() = %v, want a TimeoutError which isn't Temporary", err)
+	}
+
+	if c.BytesWritten() != 0 {
+		t.Errorf("BytesWritten() = %v, want 0", c.BytesWritten())
+	}
+}
+
+func TestNewConnClock(t *testing.T) {
+	client, server := net.Pipe()
+	defer client.Close()
+	defer server.Close()
+
+	// The system clock is accepted
+	iotimeout.NewConn(server, iotimeout.Config{Clock: datetime.SystemClock{}})
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("NewConn() didn't panic for a ManualClock")
+		}
+	}()
+
+	clock := datetime.NewManualClock(datetime.NowClock())
+	iotimeout.NewConn(server, iotimeout.Config{Clock: clock})
+}
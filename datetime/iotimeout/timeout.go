@@ -0,0 +1,238 @@
+/*
+Package iotimeout bounds how long reads and writes may take.
+
+Two limits can be combined:
+
+	Idle      each Read or Write must complete within this Duration
+	Deadline  all of them must complete by this ClockTime
+
+A stalled operation fails with a *TimeoutError, which reports how many bytes were
+transferred before and how long after the wrapper was created:
+
+	r := iotimeout.NewReader(body, iotimeout.Config{
+		Idle:     datetime.Seconds(30),
+		Deadline: datetime.NowClock().Add(datetime.Minutes(10)),
+	})
+	if _, err := io.Copy(dst, r); errors.Is(err, iotimeout.ErrIdleTimeout) {
+		log.Printf("client stalled: %v", err)
+	}
+
+An io.Reader or io.Writer can't be interrupted, so Reader and Writer call it in a
+goroutine and stop waiting when a limit is reached. The call keeps running until it
+returns, and the wrapper is unusable from then on, every later call returns the same
+error. Conn sets deadlines of the connection instead, so it doesn't need goroutines and
+stays usable after an idle timeout.
+*/
+package iotimeout
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ram-nad/go-utils/datetime"
+)
+
+// Config are the limits of a wrapper, zero values mean no limit
+type Config struct {
+	// Idle is the longest a single Read or Write may take
+	Idle datetime.Duration
+	// Deadline is the time by which all operations must be done
+	Deadline datetime.ClockTime
+	// Clock is the source of time of Reader and Writer, nil means datetime.SystemClock.
+	// Conn only supports the system clock, NewConn panics for other clocks
+	Clock datetime.Clock
+}
+
+// TimeoutError is returned when an operation reaches a limit
+type TimeoutError struct {
+	// Op is "read" or "write"
+	Op string
+	// Err is ErrIdleTimeout or ErrDeadlineExceeded
+	Err error
+	// Bytes is the number of bytes transferred in the direction of Op
+	Bytes int64
+	// Elapsed is the time since the wrapper was created
+	Elapsed datetime.Duration
+	// temporary is set for idle timeouts of a Conn, which may succeed when retried
+	temporary bool
+}
+
+// Reader is an io.Reader with time limits
+type Reader struct {
+	r     io.Reader
+	guard guard
+	buf   []byte
+}
+
+// Writer is an io.Writer with time limits
+type Writer struct {
+	w     io.Writer
+	guard guard
+	buf   []byte
+}
+
+// guard enforces limits of a single direction
+type guard struct {
+	config Config
+	start  datetime.ClockTime
+	bytes  int64
+	// err is the timeout that made a Reader or Writer unusable
+	err error
+}
+
+// result is the outcome of a call running in a goroutine
+type result struct {
+	n   int
+	err error
+}
+
+var (
+	// ErrIdleTimeout is the cause of timeouts of a single operation
+	ErrIdleTimeout = errors.New("iotimeout: idle timeout")
+	// ErrDeadlineExceeded is the cause of timeouts at the deadline
+	ErrDeadlineExceeded = errors.New("iotimeout: deadline exceeded")
+)
+
+// Error returns description of the timeout
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("%v on %s after %d bytes in %v", e.Err, e.Op, e.Bytes, e.Elapsed)
+}
+
+// Unwrap returns the cause and os.ErrDeadlineExceeded, like timeouts of net.Conn
+func (e *TimeoutError) Unwrap() []error {
+	return []error{e.Err, os.ErrDeadlineExceeded}
+}
+
+// Timeout reports true, it implements net.Error
+func (e *TimeoutError) Timeout() bool {
+	return true
+}
+
+// Temporary reports whether the operation may succeed when retried, which is only the
+// case for idle timeouts of a Conn. Reader and Writer stay failed after a timeout
+func (e *TimeoutError) Temporary() bool {
+	return e.temporary
+}
+
+func newGuard(config Config) guard {
+	if config.Clock == nil {
+		config.Clock = datetime.SystemClock{}
+	}
+
+	return guard{config: config, start: config.Clock.Now()}
+}
+
+// budget returns how long an operation starting at now may take, limited is false if
+// there is no limit
+func (g *guard) budget(now datetime.ClockTime) (wait datetime.Duration, limited bool) {
+	if g.config.Idle > 0 {
+		wait, limited = g.config.Idle, true
+	}
+
+	if g.config.Deadline != 0 {
+		untilDeadline := g.config.Deadline.Sub(now)
+		if !limited || untilDeadline < wait {
+			wait, limited = untilDeadline, true
+		}
+	}
+
+	return wait, limited
+}
+
+// timeout returns the error of an operation on op timing out at now
+func (g *guard) timeout(op string, now datetime.ClockTime) *TimeoutError {
+	cause := ErrIdleTimeout
+	if g.config.Deadline != 0 && now >= g.config.Deadline {
+		cause = ErrDeadlineExceeded
+	}
+
+	return &TimeoutError{Op: op, Err: cause, Bytes: g.bytes, Elapsed: now.Sub(g.start)}
+}
+
+// run calls f in a goroutine and waits for it within the limits
+func (g *guard) run(op string, f func() (int, error)) (int, error) {
+	if g.err != nil {
+		return 0, g.err
+	}
+
+	now := g.config.Clock.Now()
+	wait, limited := g.budget(now)
+	if !limited {
+		n, err := f()
+		g.bytes += int64(n)
+		return n, err
+	}
+
+	if wait <= 0 {
+		g.err = g.timeout(op, now)
+		return 0, g.err
+	}
+
+	done := make(chan result, 1)
+	go func() {
+		n, err := f()
+		done <- result{n: n, err: err}
+	}()
+
+	expired := make(chan struct{})
+	stop := g.config.Clock.AfterFunc(wait, func() { close(expired) })
+
+	select {
+	case r := <-done:
+		stop()
+		g.bytes += int64(r.n)
+		return r.n, r.err
+	case <-expired:
+		g.err = g.timeout(op, g.config.Clock.Now())
+		return 0, g.err
+	}
+}
+
+// NewReader wraps r with limits, the Elapsed time of errors counts from now
+func NewReader(r io.Reader, config Config) *Reader {
+	return &Reader{r: r, guard: newGuard(config)}
+}
+
+// Read reads from the underlying reader, or fails with a *TimeoutError
+func (r *Reader) Read(p []byte) (int, error) {
+	// The read may outlive this call, so it can't use p
+	if cap(r.buf) < len(p) {
+		r.buf = make([]byte, len(p))
+	}
+	buf := r.buf[:len(p)]
+
+	n, err := r.guard.run("read", func() (int, error) {
+		return r.r.Read(buf)
+	})
+	copy(p, buf[:n])
+
+	return n, err
+}
+
+// BytesRead returns the number of bytes read
+func (r *Reader) BytesRead() int64 {
+	return r.guard.bytes
+}
+
+// NewWriter wraps w with limits, the Elapsed time of errors counts from now
+func NewWriter(w io.Writer, config Config) *Writer {
+	return &Writer{w: w, guard: newGuard(config)}
+}
+
+// Write writes to the underlying writer, or fails with a *TimeoutError
+func (w *Writer) Write(p []byte) (int, error) {
+	// The write may outlive this call, so it can't use p
+	w.buf = append(w.buf[:0], p...)
+	buf := w.buf
+
+	return w.guard.run("write", func() (int, error) {
+		return w.w.Write(buf)
+	})
+}
+
+// BytesWritten returns the number of bytes written
+func (w *Writer) BytesWritten() int64 {
+	return w.guard.bytes
+}
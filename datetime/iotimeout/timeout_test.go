@@ -0,0 +1,230 @@
+package iotimeout_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ram-nad/go-utils/datetime"
+	"github.com/ram-nad/go-utils/datetime/iotimeout"
+)
+
+const start = datetime.ClockTime(1_000_000_000)
+
+// waitForWaiters waits until n calls are scheduled on the clock
+func waitForWaiters(t *testing.T, clock *datetime.ManualClock, n int) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for clock.Waiters() != n {
+		if time.Now().After(deadline) {
+			t.Fatalf("Waiters() = %v, want %v", clock.Waiters(), n)
+		}
+		runtime.Gosched()
+	}
+}
+
+// asTimeout returns err as *TimeoutError, failing the test if it isn't one
+func asTimeout(t *testing.T, err error) *iotimeout.TimeoutError {
+	t.Helper()
+
+	var timeout *iotimeout.TimeoutError
+	if !errors.As(err, &timeout) {
+		t.Fatalf("error %v is not a *TimeoutError", err)
+	}
+
+	return timeout
+}
+
+func TestReaderIdleTimeout(t *testing.T) {
+	clock := datetime.NewManualClock(start)
+	pr, pw := io.Pipe()
+	defer pr.Close()
+
+	r := iotimeout.NewReader(pr, iotimeout.Config{
+		Idle:  datetime.Seconds(5),
+		Clock: clock,
+	})
+
+	go func() { _, _ = pw.Write([]byte("hello")) }()
+
+	buf := make([]byte, 16)
+	if n, err := r.Read(buf); err != nil || string(buf[:n]) != "hello" {
+		t.Fatalf("Read() = %q, %v", buf[:n], err)
+	}
+
+	// Each read gets the whole idle time
+	clock.Advance(datetime.Seconds(4))
+
+	result := make(chan error)
+	go func() {
+		_, err := r.Read(buf)
+		result <- err
+	}()
+
+	waitForWaiters(t, clock, 1)
+	clock.Advance(datetime.Seconds(5))
+
+	err := <-result
+	timeout := asTimeout(t, err)
+	if timeout.Op != "read" || timeout.Bytes != 5 ||
+		timeout.Elapsed != datetime.Seconds(9) {
+		t.Errorf("Read() = %+v", timeout)
+	}
+
+	if !errors.Is(err, iotimeout.ErrIdleTimeout) ||
+		!errors.Is(err, os.ErrDeadlineExceeded) {
+		t.Errorf("Read() = %v, want ErrIdleTimeout and os.ErrDeadlineExceeded", err)
+	}
+
+	var netErr net.Error
+	if !errors.As(err, &netErr) || !netErr.Timeout() {
+		t.Errorf("Read() = %v, want net.Error with Timeout", err)
+	}
+
+	if timeout.Temporary() {
+		t.Errorf("Temporary() = true, but Reader stays failed after a timeout")
+	}
+
+	expected := "iotimeout: idle timeout on read after 5 bytes in 9s"
+	if err.Error() != expected {
+		t.Errorf("Error() = %q, want %q", err.Error(), expected)
+	}
+
+	// Reader is unusable after a timeout
+	go func() { _, _ = pw.Write([]byte("late")) }()
+	if _, again := r.Read(buf); again != err {
+		t.Errorf("Read() after timeout = %v, want %v", again, err)
+	}
+}
+
+func TestReaderDeadline(t *testing.T) {
+	clock := datetime.NewManualClock(start)
+	pr, _ := io.Pipe()
+	defer pr.Close()
+
+	r := iotimeout.NewReader(pr, iotimeout.Config{
+		Idle:     datetime.Seconds(30),
+		Deadline: start.Add(datetime.Seconds(10)),
+		Clock:    clock,
+	})
+
+	clock.Advance(datetime.Seconds(4))
+
+	result := make(chan error)
+	go func() {
+		_, err := r.Read(make([]byte, 1))
+		result <- err
+	}()
+
+	waitForWaiters(t, clock, 1)
+	clock.Advance(datetime.Seconds(6))
+
+	err := <-result
+	if !errors.Is(err, iotimeout.ErrDeadlineExceeded) {
+		t.Errorf("Read() = %v, want %v", err, iotimeout.ErrDeadlineExceeded)
+	}
+
+	if elapsed := asTimeout(t, err).Elapsed; elapsed != datetime.Seconds(10) {
+		t.Errorf("Elapsed = %v, want 10s", elapsed)
+	}
+}
+
+func TestReaderPastDeadline(t *testing.T) {
+	clock := datetime.NewManualClock(start)
+	r := iotimeout.NewReader(strings.NewReader("data"), iotimeout.Config{
+		Deadline: start,
+		Clock:    clock,
+	})
+
+	_, err := r.Read(make([]byte, 4))
+	if !errors.Is(err, iotimeout.ErrDeadlineExceeded) {
+		t.Errorf("Read() = %v, want %v", err, iotimeout.ErrDeadlineExceeded)
+	}
+}
+
+func TestReaderWithinLimits(t *testing.T) {
+	data := strings.Repeat("0123456789", 1000)
+
+	for _, config := range []iotimeout.Config{
+		{},
+		{Idle: datetime.Seconds(1)},
+		{Deadline: datetime.NowClock().Add(datetime.Minutes(1))},
+	} {
+		r := iotimeout.NewReader(strings.NewReader(data), config)
+
+		got, err := io.ReadAll(r)
+		if err != nil || string(got) != data || r.BytesRead() != int64(len(data)) {
+			t.Errorf("ReadAll() = %d bytes, %v with %+v", len(got), err, config)
+		}
+	}
+}
+
+func TestWriter(t *testing.T) {
+	clock := datetime.NewManualClock(start)
+	pr, pw := io.Pipe()
+	defer pr.Close()
+
+	w := iotimeout.NewWriter(pw, iotimeout.Config{
+		Idle:  datetime.Seconds(5),
+		Clock: clock,
+	})
+
+	received := make(chan []byte)
+	go func() {
+		buf := make([]byte, 16)
+		n, _ := pr.Read(buf)
+		received <- buf[:n]
+	}()
+
+	if n, err := w.Write([]byte("hello")); n != 5 || err != nil {
+		t.Fatalf("Write() = %v, %v", n, err)
+	}
+
+	if got := <-received; !bytes.Equal(got, []byte("hello")) {
+		t.Fatalf("received %q", got)
+	}
+
+	// Nobody reads any more
+	result := make(chan error)
+	go func() {
+		_, err := w.Write([]byte("stuck"))
+		result <- err
+	}()
+
+	waitForWaiters(t, clock, 1)
+	clock.Advance(datetime.Seconds(5))
+
+	err := <-result
+	if timeout := asTimeout(t, err); timeout.Op != "write" || timeout.Bytes != 5 ||
+		!errors.Is(err, iotimeout.ErrIdleTimeout) {
+		t.Errorf("Write() = %+v", timeout)
+	}
+
+	if w.BytesWritten() != 5 {
+		t.Errorf("BytesWritten() = %v, want 5", w.BytesWritten())
+	}
+}
+
+func BenchmarkReader(b *testing.B) {
+	r := iotimeout.NewReader(zeros{}, iotimeout.Config{Idle: datetime.Seconds(1)})
+	buf := make([]byte, 32*1024)
+
+	for b.Loop() {
+		_, _ = r.Read(buf)
+	}
+}
+
+// zeros is an endless reader of zero bytes
+type zeros struct{}
+
+func (zeros) Read(p []byte) (int, error) {
+	clear(p)
+	return len(p), nil
+}
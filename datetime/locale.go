@@ -0,0 +1,357 @@
+package datetime
+
+/*
+Localized formatting.
+
+A Locale has names of months and weekdays, AM/PM markers and patterns for CLDR
+skeletons. Times can be formatted with a Go layout, where the name tokens (January,
+Jan, Monday, Mon, PM, pm) are replaced with localized names, or with a CLDR pattern:
+
+	y yy      year, two digit year
+	M MM      month number
+	MMM MMMM  abbreviated and full month name (L is the same as M)
+	d dd      day of month
+	D         day of year
+	E EEEE    abbreviated and full weekday name
+	a         AM/PM marker
+	H HH      hour (0-23)
+	h hh      hour (1-12)
+	K k       hour (0-11) and hour (1-24)
+	m mm      minute
+	s ss      second
+	S...      fraction of second, truncated to the number of letters
+	X Z       offset, "Z" and "+0000"
+	'text'    quoted literal, '' is a single quote
+
+A skeleton lists the fields to show, like "yMMMd", and the locale provides the order
+and punctuation, for example "MMM d, y" in English and "d. MMM y" in German. Order of
+fields in a skeleton doesn't matter.
+
+Built in locales are en, en-GB, de, fr, es and ja. A Locale can also be created by
+filling its fields.
+*/
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+var (
+	// ErrUnknownLocale is returned when there are no built in tables for a locale tag
+	ErrUnknownLocale = errors.New("datetime: unknown locale")
+	// ErrUnknownSkeleton is returned when a locale has no pattern for a skeleton
+	ErrUnknownSkeleton = errors.New("datetime: no pattern for skeleton")
+)
+
+// Locale has localized names and patterns used for formatting
+type Locale struct {
+	// Tag is the BCP 47 language tag, like "en-GB"
+	Tag           string
+	Months        [12]string
+	ShortMonths   [12]string
+	Weekdays      [7]string // Starting with Sunday
+	ShortWeekdays [7]string
+	AM            string
+	PM            string
+	// Skeletons maps CLDR skeletons to patterns
+	Skeletons map[string]string
+}
+
+// Fields of a skeleton in canonical order
+const skeletonFieldOrder = "GyYuQqMLwWdDFgEecabBhHKkjJmsSAzZOvVXx"
+
+/*
+LookupLocale returns the built in locale for a BCP 47 language tag.
+
+Tags are matched ignoring case and "_" is accepted in place of "-". When there is no
+exact match the language alone is used, so "de-AT" gives the "de" locale. An error
+wrapping ErrUnknownLocale is returned for unsupported languages.
+
+The returned Locale is shared and must not be modified, use Clone to customize it.
+*/
+func LookupLocale(tag string) (*Locale, error) {
+	normalized := strings.ToLower(strings.ReplaceAll(tag, "_", "-"))
+
+	if l := builtinLocale(normalized); l != nil {
+		return l, nil
+	}
+
+	language, _, _ := strings.Cut(normalized, "-")
+	if l := builtinLocale(language); l != nil {
+		return l, nil
+	}
+
+	return nil, fmt.Errorf("%w %q", ErrUnknownLocale, tag)
+}
+
+// Clone returns a copy of the locale which can be modified independently
+func (l *Locale) Clone() *Locale {
+	c := *l
+	c.Skeletons = maps.Clone(l.Skeletons)
+
+	return &c
+}
+
+// FormatLocale is like Format, but names are localized for the locale tag
+func (t Time) FormatLocale(format PrintFormat, tag string) (string, error) {
+	l, err := LookupLocale(tag)
+	if err != nil {
+		return "", err
+	}
+	return l.Format(t, format), nil
+}
+
+// Format formats the time with a Go layout, replacing names with localized names
+func (l *Locale) Format(t Time, format PrintFormat) string {
+	return string(l.AppendFormat(nil, t, format))
+}
+
+// AppendFormat is like Format but appends to dst and returns the extended buffer
+func (l *Locale) AppendFormat(dst []byte, t Time, format PrintFormat) []byte {
+	layout := string(format)
+
+	for layout != "" {
+		i, token := nextNameToken(layout)
+		dst = t.AppendFormat(dst, PrintFormat(layout[:i]))
+		if token == "" {
+			break
+		}
+
+		dst = append(dst, l.name(t, token)...)
+		layout = layout[i+len(token):]
+	}
+
+	return dst
+}
+
+/*
+nextNameToken returns position of the first Go layout token with a name.
+
+Like in time.Time.Format, "Jan" and "Mon" followed by a lower case letter are
+literal text, so "Month" is not a weekday.
+*/
+func nextNameToken(layout string) (int, string) {
+	tokens := [...]string{"January", "Jan", "Monday", "Mon", "PM", "pm"}
+
+	for i := range len(layout) {
+		for _, token := range tokens {
+			if !strings.HasPrefix(layout[i:], token) {
+				continue
+			}
+
+			next := i + len(token)
+			if (token == "Jan" || token == "Mon") && next < len(layout) &&
+				layout[next] >= 'a' && layout[next] <= 'z' {
+				continue
+			}
+
+			return i, token
+		}
+	}
+
+	return len(layout), ""
+}
+
+// name returns localized value of a Go layout token
+func (l *Locale) name(t Time, token string) string {
+	switch token {
+	case "January":
+		return l.Months[t.Month()-1]
+	case "Jan":
+		return l.ShortMonths[t.Month()-1]
+	case "Monday":
+		return l.Weekdays[t.Weekday()]
+	case "Mon":
+		return l.ShortWeekdays[t.Weekday()]
+	case "pm":
+		return strings.ToLower(l.marker(t))
+	default:
+		return l.marker(t)
+	}
+}
+
+// marker returns AM or PM marker for the time
+func (l *Locale) marker(t Time) string {
+	if t.Hour() < 12 { //nolint:mnd // noon
+		return l.AM
+	}
+	return l.PM
+}
+
+// FormatPattern formats the time with a CLDR pattern like "d MMMM y"
+func (l *Locale) FormatPattern(t Time, pattern string) string {
+	return string(l.AppendPattern(nil, t, pattern))
+}
+
+// AppendPattern is like FormatPattern but appends to dst
+func (l *Locale) AppendPattern(dst []byte, t Time, pattern string) []byte {
+	for i := 0; i < len(pattern); {
+		c := pattern[i]
+
+		switch {
+		case c == '\'':
+			var n int
+			dst, n = appendQuoted(dst, pattern[i:])
+			i += n
+		case c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z':
+			n := 1
+			for i+n < len(pattern) && pattern[i+n] == c {
+				n++
+			}
+
+			dst = l.appendPatternField(dst, t, c, n)
+			i += n
+		default:
+			dst = append(dst, c)
+			i++
+		}
+	}
+
+	return dst
+}
+
+// appendQuoted appends quoted literal at the start of s, returns bytes consumed
+func appendQuoted(dst []byte, s string) ([]byte, int) {
+	// Two quotes are a literal quote
+	if strings.HasPrefix(s, "''") {
+		return append(dst, '\''), len("''")
+	}
+
+	i := 1
+	for i < len(s) {
+		if s[i] != '\'' {
+			dst = append(dst, s[i])
+			i++
+			continue
+		}
+
+		if i+1 < len(s) && s[i+1] == '\'' {
+			dst = append(dst, '\'')
+			i += 2
+			continue
+		}
+
+		return dst, i + 1
+	}
+
+	// Unterminated literal extends to the end
+	return dst, i
+}
+
+func (l *Locale) appendPatternField(dst []byte, t Time, c byte, n int) []byte {
+	switch c {
+	case 'y':
+		year := t.Year()
+		if n == 2 { //nolint:mnd // two digit year
+			year -= century(year) * yearsPerCentury
+		}
+		return appendPadded(dst, int64(year), n, '0')
+	case 'M', 'L':
+		switch n {
+		case 1, 2: //nolint:mnd // numeric month
+			return appendPadded(dst, int64(t.Month()), n, '0')
+		case 3: //nolint:mnd // abbreviated month
+			return append(dst, l.ShortMonths[t.Month()-1]...)
+		default:
+			return append(dst, l.Months[t.Month()-1]...)
+		}
+	case 'd':
+		return appendPadded(dst, int64(t.Day()), n, '0')
+	case 'D':
+		return appendPadded(dst, int64(t.YearDay()), n, '0')
+	case 'E':
+		if n < 4 { //nolint:mnd // full weekday name
+			return append(dst, l.ShortWeekdays[t.Weekday()]...)
+		}
+		return append(dst, l.Weekdays[t.Weekday()]...)
+	case 'a':
+		return append(dst, l.marker(t)...)
+	default:
+		return appendTimeField(dst, t, c, n)
+	}
+}
+
+//nolint:mnd // hours on 12 and 24-hour clocks
+func appendTimeField(dst []byte, t Time, c byte, n int) []byte {
+	switch c {
+	case 'H':
+		return appendPadded(dst, int64(t.Hour()), n, '0')
+	case 'h':
+		return appendPadded(dst, int64(hour12(t.Hour())), n, '0')
+	case 'K':
+		return appendPadded(dst, int64(t.Hour()%12), n, '0')
+	case 'k':
+		return appendPadded(dst, int64((t.Hour()+23)%24+1), n, '0')
+	case 'm':
+		return appendPadded(dst, int64(t.Minute()), n, '0')
+	case 's':
+		return appendPadded(dst, int64(t.Second()), n, '0')
+	case 'S':
+		var frac [maxFracDigits + 1]byte
+		digits := putFraction(frac[:], t.Nanosecond(), min(n, maxFracDigits), false)
+		return append(dst, frac[1:digits]...)
+	case 'X':
+		return append(dst, 'Z')
+	case 'Z':
+		return append(dst, "+0000"...)
+	default:
+		// Unsupported fields are written as is
+		for range n {
+			dst = append(dst, c)
+		}
+		return dst
+	}
+}
+
+/*
+Pattern returns the pattern for a CLDR skeleton like "yMMMd".
+
+Skeletons are matched exactly, but order of fields doesn't matter.
+*/
+func (l *Locale) Pattern(skeleton string) (string, bool) {
+	if pattern, ok := l.Skeletons[skeleton]; ok {
+		return pattern, true
+	}
+
+	canonical := canonicalSkeleton(skeleton)
+	for s, pattern := range l.Skeletons {
+		if canonicalSkeleton(s) == canonical {
+			return pattern, true
+		}
+	}
+
+	return "", false
+}
+
+// FormatSkeleton formats the time with the locale pattern for a CLDR skeleton
+func (l *Locale) FormatSkeleton(t Time, skeleton string) (string, error) {
+	pattern, ok := l.Pattern(skeleton)
+	if !ok {
+		return "", fmt.Errorf("%w %q in locale %s", ErrUnknownSkeleton, skeleton, l.Tag)
+	}
+	return l.FormatPattern(t, pattern), nil
+}
+
+// canonicalSkeleton sorts fields of a skeleton in canonical order
+func canonicalSkeleton(skeleton string) string {
+	var fields []string
+	for i := 0; i < len(skeleton); {
+		n := 1
+		for i+n < len(skeleton) && skeleton[i+n] == skeleton[i] {
+			n++
+		}
+
+		fields = append(fields, skeleton[i:i+n])
+		i += n
+	}
+
+	slices.SortStableFunc(fields, func(a, b string) int {
+		return strings.IndexByte(skeletonFieldOrder, a[0]) -
+			strings.IndexByte(skeletonFieldOrder, b[0])
+	})
+
+	return strings.Join(fields, "")
+}
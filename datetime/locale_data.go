@@ -0,0 +1,234 @@
+package datetime
+
+import "sync"
+
+// Names and patterns below follow CLDR for each locale
+
+// builtinLocales are built on first use and shared by all lookups
+//
+//nolint:gochecknoglobals // built once, never modified
+var builtinLocales = sync.OnceValue(func() map[string]*Locale {
+	return map[string]*Locale{
+		"en":    localeEnglish(),
+		"en-gb": localeBritishEnglish(),
+		"de":    localeGerman(),
+		"fr":    localeFrench(),
+		"es":    localeSpanish(),
+		"ja":    localeJapanese(),
+	}
+})
+
+// builtinLocale returns the shared built in locale, tag must be in lower case
+func builtinLocale(tag string) *Locale {
+	return builtinLocales()[tag]
+}
+
+func localeEnglish() *Locale {
+	return &Locale{
+		Tag: "en",
+		Months: [12]string{
+			"January", "February", "March", "April", "May", "June",
+			"July", "August", "September", "October", "November", "December",
+		},
+		ShortMonths: [12]string{
+			"Jan", "Feb", "Mar", "Apr", "May", "Jun",
+			"Jul", "Aug", "Sep", "Oct", "Nov", "Dec",
+		},
+		Weekdays: [7]string{
+			"Sunday", "Monday", "Tuesday", "Wednesday",
+			"Thursday", "Friday", "Saturday",
+		},
+		ShortWeekdays: [7]string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"},
+		AM:            "AM",
+		PM:            "PM",
+		Skeletons: map[string]string{
+			"yMd":        "M/d/y",
+			"yMMM":       "MMM y",
+			"yMMMM":      "MMMM y",
+			"yMMMd":      "MMM d, y",
+			"yMMMMd":     "MMMM d, y",
+			"yMMMEd":     "EEE, MMM d, y",
+			"yMMMMEEEEd": "EEEE, MMMM d, y",
+			"MMMd":       "MMM d",
+			"MMMEd":      "EEE, MMM d",
+			"Md":         "M/d",
+			"Hm":         "HH:mm",
+			"Hms":        "HH:mm:ss",
+			"hm":         "h:mm a",
+			"hms":        "h:mm:ss a",
+		},
+	}
+}
+
+func localeBritishEnglish() *Locale {
+	l := localeEnglish()
+	l.Tag = "en-GB"
+	l.ShortMonths[8] = "Sept"
+	l.AM, l.PM = "am", "pm"
+	l.Skeletons = map[string]string{
+		"yMd":        "dd/MM/y",
+		"yMMM":       "MMM y",
+		"yMMMM":      "MMMM y",
+		"yMMMd":      "d MMM y",
+		"yMMMMd":     "d MMMM y",
+		"yMMMEd":     "EEE, d MMM y",
+		"yMMMMEEEEd": "EEEE d MMMM y",
+		"MMMd":       "d MMM",
+		"MMMEd":      "EEE d MMM",
+		"Md":         "dd/MM",
+		"Hm":         "HH:mm",
+		"Hms":        "HH:mm:ss",
+		"hm":         "h:mm a",
+		"hms":        "h:mm:ss a",
+	}
+	return l
+}
+
+func localeGerman() *Locale {
+	return &Locale{
+		Tag: "de",
+		Months: [12]string{
+			"Januar", "Februar", "März", "April", "Mai", "Juni",
+			"Juli", "August", "September", "Oktober", "November", "Dezember",
+		},
+		ShortMonths: [12]string{
+			"Jan.", "Feb.", "März", "Apr.", "Mai", "Juni",
+			"Juli", "Aug.", "Sept.", "Okt.", "Nov.", "Dez.",
+		},
+		Weekdays: [7]string{
+			"Sonntag", "Montag", "Dienstag", "Mittwoch",
+			"Donnerstag", "Freitag", "Samstag",
+		},
+		ShortWeekdays: [7]string{"So.", "Mo.", "Di.", "Mi.", "Do.", "Fr.", "Sa."},
+		AM:            "AM",
+		PM:            "PM",
+		Skeletons: map[string]string{
+			"yMd":        "d.M.y",
+			"yMMM":       "MMM y",
+			"yMMMM":      "MMMM y",
+			"yMMMd":      "d. MMM y",
+			"yMMMMd":     "d. MMMM y",
+			"yMMMEd":     "EEE, d. MMM y",
+			"yMMMMEEEEd": "EEEE, d. MMMM y",
+			"MMMd":       "d. MMM",
+			"MMMEd":      "EEE, d. MMM",
+			"Md":         "d.M.",
+			"Hm":         "HH:mm",
+			"Hms":        "HH:mm:ss",
+			"hm":         "h:mm a",
+			"hms":        "h:mm:ss a",
+		},
+	}
+}
+
+func localeFrench() *Locale {
+	return &Locale{
+		Tag: "fr",
+		Months: [12]string{
+			"janvier", "février", "mars", "avril", "mai", "juin",
+			"juillet", "août", "septembre", "octobre", "novembre", "décembre",
+		},
+		ShortMonths: [12]string{
+			"janv.", "févr.", "mars", "avr.", "mai", "juin",
+			"juil.", "août", "sept.", "oct.", "nov.", "déc.",
+		},
+		Weekdays: [7]string{
+			"dimanche", "lundi", "mardi", "mercredi",
+			"jeudi", "vendredi", "samedi",
+		},
+		ShortWeekdays: [7]string{
+			"dim.", "lun.", "mar.", "mer.", "jeu.", "ven.", "sam.",
+		},
+		AM: "AM",
+		PM: "PM",
+		Skeletons: map[string]string{
+			"yMd":        "dd/MM/y",
+			"yMMM":       "MMM y",
+			"yMMMM":      "MMMM y",
+			"yMMMd":      "d MMM y",
+			"yMMMMd":     "d MMMM y",
+			"yMMMEd":     "EEE d MMM y",
+			"yMMMMEEEEd": "EEEE d MMMM y",
+			"MMMd":       "d MMM",
+			"MMMEd":      "EEE d MMM",
+			"Md":         "dd/MM",
+			"Hm":         "HH:mm",
+			"Hms":        "HH:mm:ss",
+			"hm":         "h:mm a",
+			"hms":        "h:mm:ss a",
+		},
+	}
+}
+
+func localeSpanish() *Locale {
+	return &Locale{
+		Tag: "es",
+		Months: [12]string{
+			"enero", "febrero", "marzo", "abril", "mayo", "junio",
+			"julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre",
+		},
+		ShortMonths: [12]string{
+			"ene", "feb", "mar", "abr", "may", "jun",
+			"jul", "ago", "sept", "oct", "nov", "dic",
+		},
+		Weekdays: [7]string{
+			"domingo", "lunes", "martes", "miércoles", "jueves", "viernes", "sábado",
+		},
+		ShortWeekdays: [7]string{"dom", "lun", "mar", "mié", "jue", "vie", "sáb"},
+		AM:            "a. m.",
+		PM:            "p. m.",
+		Skeletons: map[string]string{
+			"yMd":        "d/M/y",
+			"yMMM":       "MMM y",
+			"yMMMM":      "MMMM 'de' y",
+			"yMMMd":      "d MMM y",
+			"yMMMMd":     "d 'de' MMMM 'de' y",
+			"yMMMEd":     "EEE, d MMM y",
+			"yMMMMEEEEd": "EEEE, d 'de' MMMM 'de' y",
+			"MMMd":       "d MMM",
+			"MMMEd":      "EEE, d MMM",
+			"Md":         "d/M",
+			"Hm":         "H:mm",
+			"Hms":        "H:mm:ss",
+			"hm":         "h:mm a",
+			"hms":        "h:mm:ss a",
+		},
+	}
+}
+
+func localeJapanese() *Locale {
+	return &Locale{
+		Tag: "ja",
+		Months: [12]string{
+			"1月", "2月", "3月", "4月", "5月", "6月",
+			"7月", "8月", "9月", "10月", "11月", "12月",
+		},
+		ShortMonths: [12]string{
+			"1月", "2月", "3月", "4月", "5月", "6月",
+			"7月", "8月", "9月", "10月", "11月", "12月",
+		},
+		Weekdays: [7]string{
+			"日曜日", "月曜日", "火曜日", "水曜日",
+			"木曜日", "金曜日", "土曜日",
+		},
+		ShortWeekdays: [7]string{"日", "月", "火", "水", "木", "金", "土"},
+		AM:            "午前",
+		PM:            "午後",
+		Skeletons: map[string]string{
+			"yMd":        "y/M/d",
+			"yMMM":       "y年M月",
+			"yMMMM":      "y年M月",
+			"yMMMd":      "y年M月d日",
+			"yMMMMd":     "y年M月d日",
+			"yMMMEd":     "y年M月d日(EEE)",
+			"yMMMMEEEEd": "y年M月d日EEEE",
+			"MMMd":       "M月d日",
+			"MMMEd":      "M月d日(EEE)",
+			"Md":         "M/d",
+			"Hm":         "H:mm",
+			"Hms":        "H:mm:ss",
+			"hm":         "aK:mm",
+			"hms":        "aK:mm:ss",
+		},
+	}
+}
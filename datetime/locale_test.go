@@ -0,0 +1,216 @@
+package datetime_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/ram-nad/go-utils/datetime"
+)
+
+func TestLookupLocale(t *testing.T) {
+	tests := []struct {
+		tag      string
+		expected string
+	}{
+		{"en", "en"},
+		{"en-US", "en"},
+		{"en_gb", "en-GB"},
+		{"EN-GB", "en-GB"},
+		{"de-AT", "de"},
+		{"fr-CA", "fr"},
+		{"es", "es"},
+		{"ja-JP", "ja"},
+	}
+
+	for _, tc := range tests {
+		l, err := datetime.LookupLocale(tc.tag)
+		if err != nil || l.Tag != tc.expected {
+			t.Errorf("LookupLocale(%q) = %v, %v, want %s", tc.tag, l, err, tc.expected)
+		}
+	}
+
+	_, err := datetime.LookupLocale("xx-YY")
+	if !errors.Is(err, datetime.ErrUnknownLocale) {
+		t.Errorf("LookupLocale(xx-YY) = %v, want ErrUnknownLocale", err)
+	}
+}
+
+func TestLocaleFormat(t *testing.T) {
+	dt := datetime.Date(2021, 3, 7, 15, 4, 5, 0)
+
+	tests := []struct {
+		tag      string
+		format   datetime.PrintFormat
+		expected string
+	}{
+		{"en", datetime.RFC1123, "Sun, 07 Mar 2021 15:04:05 UTC"},
+		{"de", "Monday, 2. January 2006", "Sonntag, 7. März 2021"},
+		{"fr", "Mon 2 Jan 2006", "dim. 7 mars 2021"},
+		{"es", "Monday 2 January, 3:04 PM", "domingo 7 marzo, 3:04 p. m."},
+		{"en-GB", "3:04pm Mon", "3:04pm Sun"},
+		{"ja", "January 2日 (Mon) PM3時", "3月 7日 (日) 午後3時"},
+		{"de", datetime.RFC3339, "2021-03-07T15:04:05Z"},
+	}
+
+	for _, tc := range tests {
+		got, err := dt.FormatLocale(tc.format, tc.tag)
+		if err != nil || got != tc.expected {
+			t.Errorf(
+				"FormatLocale(%q, %s) = %q, %v, want %q",
+				tc.format,
+				tc.tag,
+				got,
+				err,
+				tc.expected,
+			)
+		}
+	}
+
+	// English locale matches Format for any layout
+	en, _ := datetime.LookupLocale("en")
+	for _, layout := range []datetime.PrintFormat{
+		datetime.RFC822,
+		datetime.RFC1123Z,
+		"Monday January 2 3:04:05.000 pm",
+		"Month Janet",
+	} {
+		if got, expected := en.Format(dt, layout), dt.Format(layout); got != expected {
+			t.Errorf("Format(%q) = %q, want %q", layout, got, expected)
+		}
+	}
+}
+
+func TestLocaleFormatPattern(t *testing.T) {
+	dt := datetime.Date(2021, 3, 7, 9, 4, 5, 123456789)
+	en, _ := datetime.LookupLocale("en")
+
+	tests := []struct {
+		pattern  string
+		expected string
+	}{
+		{"y-MM-dd HH:mm:ss.SSS", "2021-03-07 09:04:05.123"},
+		{"yy M d H m s S", "21 3 7 9 4 5 1"},
+		{"EEEE, MMMM d, y", "Sunday, March 7, 2021"},
+		{"EEE LLL", "Sun Mar"},
+		{"h:mm a K k", "9:04 AM 9 9"},
+		{"D DDD", "66 066"},
+		{"'Day' d 'of' MMMM", "Day 7 of March"},
+		{"HH 'o''clock' ''", "09 o'clock '"},
+		{"yyyy-MM-dd'T'HH:mm:ssX", "2021-03-07T09:04:05Z"},
+		{"HHmm Z", "0904 +0000"},
+		{"'unterminated", "unterminated"},
+	}
+
+	for _, tc := range tests {
+		if got := en.FormatPattern(dt, tc.pattern); got != tc.expected {
+			t.Errorf("FormatPattern(%q) = %q, want %q", tc.pattern, got, tc.expected)
+		}
+	}
+
+	midnight := datetime.Date(2021, 3, 7, 0, 30, 0, 0)
+	if got := en.FormatPattern(midnight, "h K k H"); got != "12 0 24 0" {
+		t.Errorf("FormatPattern(h K k H) = %q, want %q", got, "12 0 24 0")
+	}
+}
+
+func TestLocaleFormatSkeleton(t *testing.T) {
+	dt := datetime.Date(2021, 9, 7, 15, 4, 5, 0)
+
+	tests := []struct {
+		tag      string
+		skeleton string
+		expected string
+	}{
+		{"en", "yMMMd", "Sep 7, 2021"},
+		{"en-GB", "yMMMd", "7 Sept 2021"},
+		{"de", "yMMMd", "7. Sept. 2021"},
+		{"fr", "yMMMd", "7 sept. 2021"},
+		{"es", "yMMMd", "7 sept 2021"},
+		{"ja", "yMMMd", "2021年9月7日"},
+		{"en", "yMd", "9/7/2021"},
+		{"en-GB", "yMd", "07/09/2021"},
+		{"de", "yMMMMEEEEd", "Dienstag, 7. September 2021"},
+		{"es", "yMMMMd", "7 de septiembre de 2021"},
+		{"ja", "MMMEd", "9月7日(火)"},
+		{"en", "hm", "3:04 PM"},
+		{"es", "hm", "3:04 p. m."},
+		{"ja", "hms", "午後3:04:05"},
+		{"de", "Hm", "15:04"},
+		// Order of fields doesn't matter
+		{"fr", "dMMMy", "7 sept. 2021"},
+	}
+
+	for _, tc := range tests {
+		l, _ := datetime.LookupLocale(tc.tag)
+		got, err := l.FormatSkeleton(dt, tc.skeleton)
+		if err != nil || got != tc.expected {
+			t.Errorf(
+				"%s.FormatSkeleton(%q) = %q, %v, want %q",
+				tc.tag,
+				tc.skeleton,
+				got,
+				err,
+				tc.expected,
+			)
+		}
+	}
+
+	en, _ := datetime.LookupLocale("en")
+	_, err := en.FormatSkeleton(dt, "GGGGy")
+	if !errors.Is(err, datetime.ErrUnknownSkeleton) {
+		t.Errorf("FormatSkeleton(GGGGy) = %v, want ErrUnknownSkeleton", err)
+	}
+}
+
+func TestCustomLocale(t *testing.T) {
+	en, _ := datetime.LookupLocale("en")
+	l := en.Clone()
+	l.Tag = "en-x-pirate"
+	l.AM, l.PM = "in the morn'", "in the eve"
+	l.Skeletons["hm"] = "h:mm a"
+
+	dt := datetime.Date(2021, 3, 7, 20, 0, 0, 0)
+	if got, _ := l.FormatSkeleton(dt, "hm"); got != "8:00 in the eve" {
+		t.Errorf("FormatSkeleton(hm) = %q, want %q", got, "8:00 in the eve")
+	}
+
+	// Built in tables are not affected
+	en, _ = datetime.LookupLocale("en")
+	if got, _ := en.FormatSkeleton(dt, "hm"); got != "8:00 PM" {
+		t.Errorf("FormatSkeleton(hm) = %q, want %q", got, "8:00 PM")
+	}
+}
+
+func TestLookupLocaleShared(t *testing.T) {
+	first, _ := datetime.LookupLocale("de-AT")
+	second, _ := datetime.LookupLocale("de")
+	if first != second {
+		t.Errorf("LookupLocale() returned different copies of the same locale")
+	}
+
+	// Tags in lower case are looked up without copying
+	allocs := testing.AllocsPerRun(100, func() {
+		_, _ = datetime.LookupLocale("fr-ca")
+	})
+	if allocs != 0 {
+		t.Errorf("LookupLocale() allocates %v times, want 0", allocs)
+	}
+}
+
+func ExampleLocale_FormatSkeleton() {
+	dt := datetime.Date(2021, 3, 7, 0, 0, 0, 0)
+
+	for _, tag := range []string{"en-US", "en-GB", "de-DE", "fr-FR", "es-ES", "ja-JP"} {
+		l, _ := datetime.LookupLocale(tag)
+		s, _ := l.FormatSkeleton(dt, "yMMMMEEEEd")
+		_, _ = fmt.Println(tag, s)
+	}
+	// Output:
+	// en-US Sunday, March 7, 2021
+	// en-GB Sunday 7 March 2021
+	// de-DE Sonntag, 7. März 2021
+	// fr-FR dimanche 7 mars 2021
+	// es-ES domingo, 7 de marzo de 2021
+	// ja-JP 2021年3月7日日曜日
+}
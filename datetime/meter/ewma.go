@@ -0,0 +1,206 @@
+/*
+Package meter measures rates of events and smooths Duration samples with exponentially
+weighted moving averages.
+
+Averages are updated once per tick interval, like the load average of Unix, which
+makes them independent of how often events happen. There is no goroutine, ticks that
+passed are applied when the average is next updated or read, given the ClockTime of
+that call. Missed ticks decay the average in a single step.
+
+After each tick the rate moves towards the rate of the last interval by
+
+	alpha = 1 - exp(-interval / window)
+
+so an event counts about 1/e as much after one window as right after it happened.
+
+	requests := meter.NewMeter(datetime.NowClock())
+	requests.Mark(1, datetime.NowClock())
+	...
+	snap := requests.Snapshot(datetime.NowClock())
+	log.Printf("%.1f req/s over the last minute", snap.Rate1)
+*/
+package meter
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/ram-nad/go-utils/datetime"
+)
+
+// EWMA is an exponentially weighted moving average of an event rate, it is safe for
+// concurrent use
+type EWMA struct {
+	mu       sync.Mutex
+	ticker   ticker
+	pending  int64
+	rate     float64
+	hasValue bool
+}
+
+// Average is an exponentially weighted moving average of Duration samples, it is safe
+// for concurrent use
+type Average struct {
+	mu     sync.Mutex
+	ticker ticker
+	// sum and n are of samples since the last tick
+	sum      float64
+	n        int64
+	count    int64
+	value    float64
+	hasValue bool
+}
+
+// ticker counts ticks passed since the last update
+type ticker struct {
+	interval datetime.Duration
+	alpha    float64
+	last     datetime.ClockTime
+}
+
+// DefaultInterval is the tick interval of meters, the one of Unix load averages
+const DefaultInterval = 5 * datetime.Duration(time.Second)
+
+/*
+NewEWMA creates a rate average over window, ticking every interval from start.
+
+NewEWMA panics if window or interval is not positive.
+*/
+func NewEWMA(window, interval datetime.Duration, start datetime.ClockTime) *EWMA {
+	return &EWMA{ticker: newTicker(window, interval, start, "NewEWMA")}
+}
+
+/*
+NewAverage creates an average of samples over window, ticking every interval from
+start.
+
+NewAverage panics if window or interval is not positive.
+*/
+func NewAverage(window, interval datetime.Duration, start datetime.ClockTime) *Average {
+	return &Average{ticker: newTicker(window, interval, start, "NewAverage")}
+}
+
+func newTicker(
+	window, interval datetime.Duration,
+	start datetime.ClockTime,
+	caller string,
+) ticker {
+	if window <= 0 || interval <= 0 {
+		panic("meter: non-positive window or interval for " + caller)
+	}
+
+	return ticker{
+		interval: interval,
+		alpha:    1 - math.Exp(-float64(interval)/float64(window)),
+		last:     start,
+	}
+}
+
+// advance returns number of ticks passed by now and moves the last tick
+func (t *ticker) advance(now datetime.ClockTime) int64 {
+	elapsed := now.Sub(t.last)
+	if elapsed < t.interval {
+		return 0
+	}
+
+	ticks := int64(elapsed / t.interval)
+	t.last = t.last.Add(datetime.Duration(ticks) * t.interval)
+
+	return ticks
+}
+
+// decay returns the weight an average keeps after ticks ticks without events
+func (t *ticker) decay(ticks int64) float64 {
+	return math.Pow(1-t.alpha, float64(ticks))
+}
+
+// Update counts n events happening at now
+func (e *EWMA) Update(n int64, now datetime.ClockTime) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.tick(now)
+	e.pending += n
+}
+
+// Rate returns the average rate per second as of the last tick before now
+func (e *EWMA) Rate(now datetime.ClockTime) float64 {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.tick(now)
+	return e.rate
+}
+
+// tick applies ticks passed by now, events pending are in the first of them
+func (e *EWMA) tick(now datetime.ClockTime) {
+	ticks := e.ticker.advance(now)
+	if ticks == 0 {
+		return
+	}
+
+	instant := float64(e.pending) / time.Duration(e.ticker.interval).Seconds()
+	e.pending = 0
+
+	if e.hasValue {
+		e.rate += e.ticker.alpha * (instant - e.rate)
+	} else {
+		e.rate, e.hasValue = instant, true
+	}
+
+	e.rate *= e.ticker.decay(ticks - 1)
+}
+
+// Observe adds a sample taken at now
+func (a *Average) Observe(d datetime.Duration, now datetime.ClockTime) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.tick(now)
+	a.sum += float64(d)
+	a.n++
+	a.count++
+}
+
+/*
+Value returns the average as of the last tick before now.
+
+Intervals without samples leave the average as it was. Before the first tick it is the
+mean of samples so far, zero without samples.
+*/
+func (a *Average) Value(now datetime.ClockTime) datetime.Duration {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.tick(now)
+	if !a.hasValue && a.n > 0 {
+		return datetime.Duration(a.sum / float64(a.n))
+	}
+
+	return datetime.Duration(a.value)
+}
+
+// Count returns the number of samples observed
+func (a *Average) Count() int64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.count
+}
+
+// tick folds the mean of samples since the last tick into the average
+func (a *Average) tick(now datetime.ClockTime) {
+	if a.ticker.advance(now) == 0 || a.n == 0 {
+		return
+	}
+
+	mean := a.sum / float64(a.n)
+	a.sum, a.n = 0, 0
+
+	if a.hasValue {
+		a.value += a.ticker.alpha * (mean - a.value)
+	} else {
+		a.value, a.hasValue = mean, true
+	}
+}
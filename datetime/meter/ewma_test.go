@@ -0,0 +1,163 @@
+package meter_test
+
+import (
+	"math"
+	"sync"
+	"testing"
+
+	"github.com/ram-nad/go-utils/datetime"
+	"github.com/ram-nad/go-utils/datetime/meter"
+)
+
+const start = datetime.ClockTime(1_000_000_000)
+
+// near reports whether got is within a millionth of expected
+func near(got, expected float64) bool {
+	return math.Abs(got-expected) <= 1e-6*math.Max(math.Abs(expected), 1)
+}
+
+func TestEWMADecay(t *testing.T) {
+	e := meter.NewEWMA(datetime.Minutes(1), meter.DefaultInterval, start)
+
+	e.Update(3, start)
+	if rate := e.Rate(start.Add(datetime.Seconds(4))); rate != 0 {
+		t.Errorf("Rate() = %v before first tick, want 0", rate)
+	}
+
+	// Reference values of Dropwizard Metrics
+	tests := []struct {
+		minutes  int64
+		expected float64
+	}{
+		{0, 0.6},
+		{1, 0.22072766},
+		{2, 0.08120117},
+		{5, 0.00404277},
+		{10, 0.00002724},
+	}
+
+	for _, tc := range tests {
+		now := start.Add(meter.DefaultInterval + datetime.Minutes(tc.minutes))
+		if got := e.Rate(now); math.Abs(got-tc.expected) > 1e-8 {
+			t.Errorf(
+				"Rate() after %d minutes = %.8f, want %.8f", tc.minutes, got, tc.expected,
+			)
+		}
+	}
+}
+
+func TestEWMALazyTicks(t *testing.T) {
+	eager := meter.NewEWMA(datetime.Minutes(5), meter.DefaultInterval, start)
+	lazy := meter.NewEWMA(datetime.Minutes(5), meter.DefaultInterval, start)
+
+	now := start
+	for i := range int64(100) {
+		eager.Update(i, now)
+		lazy.Update(i, now)
+		now = now.Add(meter.DefaultInterval)
+
+		// Ticks without events
+		for range 3 {
+			_ = eager.Rate(now)
+			now = now.Add(meter.DefaultInterval)
+		}
+	}
+
+	if got, expected := lazy.Rate(now), eager.Rate(now); !near(got, expected) {
+		t.Errorf("Rate() ticked lazily = %v, want %v", got, expected)
+	}
+}
+
+func TestEWMAConstantRate(t *testing.T) {
+	e := meter.NewEWMA(datetime.Minutes(15), datetime.Seconds(1), start)
+
+	now := start
+	for range 600 {
+		e.Update(7, now)
+		now = now.Add(datetime.Milliseconds(500))
+		e.Update(3, now)
+		now = now.Add(datetime.Milliseconds(500))
+	}
+
+	if rate := e.Rate(now); !near(rate, 10) {
+		t.Errorf("Rate() = %v, want 10", rate)
+	}
+}
+
+func TestAverage(t *testing.T) {
+	window := datetime.Minutes(1)
+	a := meter.NewAverage(window, datetime.Seconds(1), start)
+
+	if v := a.Value(start); v != 0 {
+		t.Errorf("Value() = %v without samples, want 0", v)
+	}
+
+	a.Observe(datetime.Milliseconds(100), start)
+	a.Observe(datetime.Milliseconds(300), start)
+	if v := a.Value(start); v != datetime.Milliseconds(200) {
+		t.Errorf("Value() = %v before first tick, want 200ms", v)
+	}
+
+	// Intervals without samples don't change the average
+	now := start.Add(datetime.Minutes(10))
+	if v := a.Value(now); v != datetime.Milliseconds(200) {
+		t.Errorf("Value() = %v after idle intervals, want 200ms", v)
+	}
+
+	a.Observe(datetime.Milliseconds(400), now)
+	now = now.Add(datetime.Seconds(1))
+
+	alpha := 1 - math.Exp(-1.0/60)
+	expected := datetime.Duration(200e6 + alpha*200e6)
+	if v := a.Value(now); (v - expected).Abs() > 1 {
+		t.Errorf("Value() = %v, want %v", v, expected)
+	}
+
+	if a.Count() != 3 {
+		t.Errorf("Count() = %v, want 3", a.Count())
+	}
+}
+
+func TestNewEWMAPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("NewEWMA() with zero interval didn't panic")
+		}
+	}()
+
+	meter.NewEWMA(datetime.Minutes(1), 0, start)
+}
+
+func TestConcurrentUpdates(t *testing.T) {
+	e := meter.NewEWMA(datetime.Minutes(1), datetime.Seconds(1), start)
+	a := meter.NewAverage(datetime.Minutes(1), datetime.Seconds(1), start)
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Go(func() {
+			for i := range int64(1000) {
+				e.Update(1, start.Add(datetime.Milliseconds(i)))
+				a.Observe(datetime.Milliseconds(5), start.Add(datetime.Milliseconds(i)))
+			}
+		})
+	}
+	wg.Wait()
+
+	if rate := e.Rate(start.Add(datetime.Seconds(1))); rate != 8000 {
+		t.Errorf("Rate() = %v, want 8000", rate)
+	}
+
+	if v := a.Value(start.Add(datetime.Seconds(1))); v != datetime.Milliseconds(5) {
+		t.Errorf("Value() = %v, want 5ms", v)
+	}
+}
+
+func BenchmarkEWMAUpdate(b *testing.B) {
+	e := meter.NewEWMA(datetime.Minutes(1), meter.DefaultInterval, start)
+	now := start
+
+	for b.Loop() {
+		e.Update(1, now)
+		now = now.Add(datetime.Microseconds(1))
+	}
+}
@@ -0,0 +1,84 @@
+package meter
+
+/*
+Meter combines three EWMAs over 1, 5 and 15 minutes with the total count of events and
+their mean rate since the start, like the meters of Dropwizard Metrics.
+
+A Snapshot is a plain struct of the values at one time, ready to be logged or encoded
+as JSON.
+*/
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/ram-nad/go-utils/datetime"
+)
+
+// Meter measures the rate of events, it is safe for concurrent use
+type Meter struct {
+	start  datetime.ClockTime
+	count  atomic.Int64
+	rate1  *EWMA
+	rate5  *EWMA
+	rate15 *EWMA
+}
+
+// Snapshot are the values of a Meter at one time, rates are per second
+type Snapshot struct {
+	Count    int64
+	Rate1    float64
+	Rate5    float64
+	Rate15   float64
+	MeanRate float64
+}
+
+// NewMeter creates a meter starting at start, ticking every DefaultInterval
+//
+//nolint:mnd // windows of load averages
+func NewMeter(start datetime.ClockTime) *Meter {
+	return &Meter{
+		start:  start,
+		rate1:  NewEWMA(datetime.Minutes(1), DefaultInterval, start),
+		rate5:  NewEWMA(datetime.Minutes(5), DefaultInterval, start),
+		rate15: NewEWMA(datetime.Minutes(15), DefaultInterval, start),
+	}
+}
+
+// Mark records n events happening at now
+func (m *Meter) Mark(n int64, now datetime.ClockTime) {
+	m.count.Add(n)
+	m.rate1.Update(n, now)
+	m.rate5.Update(n, now)
+	m.rate15.Update(n, now)
+}
+
+// Count returns the number of events marked
+func (m *Meter) Count() int64 {
+	return m.count.Load()
+}
+
+// Snapshot returns the values of the meter at now
+func (m *Meter) Snapshot(now datetime.ClockTime) Snapshot {
+	s := Snapshot{
+		Count:  m.count.Load(),
+		Rate1:  m.rate1.Rate(now),
+		Rate5:  m.rate5.Rate(now),
+		Rate15: m.rate15.Rate(now),
+	}
+
+	if elapsed := now.Sub(m.start); elapsed > 0 {
+		s.MeanRate = float64(s.Count) / time.Duration(elapsed).Seconds()
+	}
+
+	return s
+}
+
+// String returns the snapshot like the load average of uptime
+func (s Snapshot) String() string {
+	return fmt.Sprintf(
+		"count %d, rate %.2f, %.2f, %.2f/s, mean %.2f/s",
+		s.Count, s.Rate1, s.Rate5, s.Rate15, s.MeanRate,
+	)
+}
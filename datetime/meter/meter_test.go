@@ -0,0 +1,72 @@
+package meter_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/ram-nad/go-utils/datetime"
+	"github.com/ram-nad/go-utils/datetime/meter"
+)
+
+func TestMeter(t *testing.T) {
+	m := meter.NewMeter(start)
+
+	// 2 events per second for 10 minutes, then nothing for a minute
+	now := start
+	for range 600 {
+		m.Mark(2, now)
+		now = now.Add(datetime.Seconds(1))
+	}
+
+	s := m.Snapshot(now)
+	if s.Count != 1200 || !near(s.Rate1, 2) || !near(s.Rate5, 2) || !near(s.Rate15, 2) {
+		t.Fatalf("Snapshot() = %v at constant rate", s)
+	}
+
+	now = now.Add(datetime.Minutes(1))
+	s = m.Snapshot(now)
+
+	tests := []struct {
+		name          string
+		got, expected float64
+	}{
+		{"Rate1", s.Rate1, 2 * math.Exp(-1)},
+		{"Rate5", s.Rate5, 2 * math.Exp(-1.0/5)},
+		{"Rate15", s.Rate15, 2 * math.Exp(-1.0/15)},
+		{"MeanRate", s.MeanRate, 1200.0 / 660},
+	}
+
+	for _, tc := range tests {
+		if !near(tc.got, tc.expected) {
+			t.Errorf("%s = %v a minute later, want %v", tc.name, tc.got, tc.expected)
+		}
+	}
+
+	if m.Count() != 1200 {
+		t.Errorf("Count() = %v, want 1200", m.Count())
+	}
+}
+
+func TestMeterBeforeStart(t *testing.T) {
+	m := meter.NewMeter(start)
+
+	if s := m.Snapshot(start); s != (meter.Snapshot{}) {
+		t.Errorf("Snapshot() = %v at start", s)
+	}
+}
+
+func ExampleMeter() {
+	start := datetime.ClockTime(0)
+	m := meter.NewMeter(start)
+
+	now := start
+	for range 60 {
+		m.Mark(5, now)
+		now = now.Add(datetime.Seconds(1))
+	}
+
+	_, _ = fmt.Println(m.Snapshot(now))
+	// Output:
+	// count 300, rate 5.00, 5.00, 5.00/s, mean 5.00/s
+}
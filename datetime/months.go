@@ -0,0 +1,182 @@
+package datetime
+
+/*
+Month arithmetic with explicit handling of days that don't exist in the target month.
+
+AddDate normalizes overflowing days, so Jan 31 + 1 month is Mar 03 (or Mar 02 in leap
+years). AddMonths and AddYears take an OverflowPolicy instead:
+
+	                  Jan 31 + 1 month    Feb 28 + 1 month    Jan 30 + 1 month
+	ClampToMonthEnd   Feb 28              Mar 28              Feb 28
+	PreserveMonthEnd  Feb 28              Mar 31              Feb 28
+	NormalizeOverflow Mar 03              Mar 28              Mar 02
+	ErrorOnOverflow   ErrMonthOverflow    Mar 28              ErrMonthOverflow
+
+Repeatedly adding months to the previous result drifts (Jan 31 -> Feb 28 -> Mar 28).
+MonthlySchedule always computes occurrences from the original anchor to avoid that. With
+ErrorOnOverflow a schedule skips occurrences on missing days instead of failing.
+*/
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrMonthOverflow is returned when the day doesn't exist in the target month
+var ErrMonthOverflow = errors.New("datetime: day does not exist in target month")
+
+// OverflowPolicy decides what happens when month arithmetic lands on a nonexistent day
+type OverflowPolicy int
+
+const (
+	// ClampToMonthEnd uses the last day of the target month
+	ClampToMonthEnd OverflowPolicy = iota
+	// PreserveMonthEnd is like ClampToMonthEnd, but last day of a month maps to
+	// last day of the target month
+	PreserveMonthEnd
+	// NormalizeOverflow carries extra days into the next month, like AddDate
+	NormalizeOverflow
+	// ErrorOnOverflow returns ErrMonthOverflow
+	ErrorOnOverflow
+)
+
+const monthsPerYear = 12
+
+// MonthlySchedule generates dates every Interval months from Anchor without drift
+type MonthlySchedule struct {
+	Anchor   Time
+	Interval int
+	Policy   OverflowPolicy
+}
+
+// String returns name of the policy
+func (p OverflowPolicy) String() string {
+	switch p {
+	case ClampToMonthEnd:
+		return "ClampToMonthEnd"
+	case PreserveMonthEnd:
+		return "PreserveMonthEnd"
+	case NormalizeOverflow:
+		return "NormalizeOverflow"
+	case ErrorOnOverflow:
+		return "ErrorOnOverflow"
+	default:
+		return fmt.Sprintf("OverflowPolicy(%d)", int(p))
+	}
+}
+
+/*
+AddMonths adds months to the time, handling days missing in the target month
+according to policy. Time of day is preserved.
+
+An error is only returned for ErrorOnOverflow or an unknown policy.
+*/
+func (t Time) AddMonths(months int, policy OverflowPolicy) (Time, error) {
+	year, month, day := int64(t.Year()), int(t.Month()), t.Day()
+
+	total := year*monthsPerYear + int64(month-1) + int64(months)
+	newYear := floorDiv(total, monthsPerYear)
+	newMonth := Month(total-newYear*monthsPerYear) + 1
+	lastDay := daysIn(newMonth, newYear)
+
+	switch policy {
+	case ClampToMonthEnd:
+		day = min(day, lastDay)
+	case PreserveMonthEnd:
+		if day == daysIn(Month(month), year) {
+			day = lastDay
+		}
+		day = min(day, lastDay)
+	case NormalizeOverflow:
+	case ErrorOnOverflow:
+		if day > lastDay {
+			return Time{}, ErrMonthOverflow
+		}
+	default:
+		return Time{}, fmt.Errorf("datetime: unknown overflow policy %v", policy)
+	}
+
+	return Date(
+		int(newYear),
+		int(newMonth),
+		day,
+		t.Hour(),
+		t.Minute(),
+		t.Second(),
+		t.Nanosecond(),
+	), nil
+}
+
+// AddYears adds years to the time, Feb 29 is handled according to policy
+func (t Time) AddYears(years int, policy OverflowPolicy) (Time, error) {
+	return t.AddMonths(years*monthsPerYear, policy)
+}
+
+// NewMonthlySchedule creates schedule for dates every interval months from anchor
+func NewMonthlySchedule(
+	anchor Time,
+	interval int,
+	policy OverflowPolicy,
+) MonthlySchedule {
+	return MonthlySchedule{Anchor: anchor, Interval: interval, Policy: policy}
+}
+
+// Occurrence returns n-th date of the schedule, Anchor is occurrence 0
+func (s MonthlySchedule) Occurrence(n int) (Time, error) {
+	return s.Anchor.AddMonths(n*s.Interval, s.Policy)
+}
+
+/*
+Next returns the first occurrence strictly after t, together with its index.
+
+Occurrences before Anchor are not considered, so Anchor is returned for any t before
+it. Interval must be positive. With ErrorOnOverflow occurrences on days missing in
+their month are skipped, a schedule anchored on the 31st has no occurrence in April.
+*/
+func (s MonthlySchedule) Next(t Time) (Time, int, error) {
+	if s.Interval <= 0 {
+		err := fmt.Errorf("datetime: invalid schedule interval %d", s.Interval)
+		return Time{}, 0, err
+	}
+
+	years, months := t.Year()-s.Anchor.Year(), int(t.Month()-s.Anchor.Month())
+	months += years * monthsPerYear
+	// Start one period early, an occurrence may land before t in the same month
+	n := max(months/s.Interval-1, 0)
+
+	for {
+		occurrence, index, err := s.existing(n)
+		if err != nil || occurrence.After(t) {
+			return occurrence, index, err
+		}
+		n = index + 1
+	}
+}
+
+// Between returns all occurrences in the closed range [from, to]
+func (s MonthlySchedule) Between(from, to Time) ([]Time, error) {
+	var result []Time
+
+	occurrence, n, err := s.Next(from.Add(-1))
+	for err == nil && !occurrence.After(to) {
+		result = append(result, occurrence)
+		occurrence, n, err = s.existing(n + 1)
+	}
+
+	return result, err
+}
+
+/*
+existing returns the first occurrence from n-th on which exists, together with its
+index. Only ErrorOnOverflow skips occurrences, it finds one within 400 years as both
+months and leap years repeat.
+*/
+func (s MonthlySchedule) existing(n int) (Time, int, error) {
+	for {
+		occurrence, err := s.Occurrence(n)
+		if !errors.Is(err, ErrMonthOverflow) {
+			return occurrence, n, err
+		}
+		n++
+	}
+}
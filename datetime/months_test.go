@@ -0,0 +1,236 @@
+package datetime_test
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/ram-nad/go-utils/datetime"
+)
+
+func TestAddMonths(t *testing.T) {
+	at := func(year, month, day int) datetime.Time {
+		return datetime.Date(year, month, day, 10, 30, 0, 5)
+	}
+	jan31, feb28, jan30 := at(2021, 1, 31), at(2021, 2, 28), at(2021, 1, 30)
+
+	tests := []struct {
+		dt       datetime.Time
+		months   int
+		policy   datetime.OverflowPolicy
+		expected datetime.Time
+	}{
+		{jan31, 1, datetime.ClampToMonthEnd, feb28},
+		{jan31, 1, datetime.PreserveMonthEnd, feb28},
+		{jan31, 1, datetime.NormalizeOverflow, at(2021, 3, 3)},
+		{feb28, 1, datetime.ClampToMonthEnd, at(2021, 3, 28)},
+		{feb28, 1, datetime.PreserveMonthEnd, at(2021, 3, 31)},
+		{feb28, 1, datetime.ErrorOnOverflow, at(2021, 3, 28)},
+		{jan30, 1, datetime.PreserveMonthEnd, feb28},
+		{jan30, 1, datetime.NormalizeOverflow, at(2021, 3, 2)},
+		{jan31, -2, datetime.ClampToMonthEnd, at(2020, 11, 30)},
+		{jan31, 13, datetime.ClampToMonthEnd, at(2022, 2, 28)},
+		{jan31, 37, datetime.PreserveMonthEnd, at(2024, 2, 29)},
+		{jan31, -13, datetime.ErrorOnOverflow, at(2019, 12, 31)},
+	}
+
+	for _, tc := range tests {
+		got, err := tc.dt.AddMonths(tc.months, tc.policy)
+		if err != nil || !got.Equal(tc.expected) {
+			t.Errorf(
+				"%v.AddMonths(%d, %v) = %v, %v, want %v",
+				tc.dt,
+				tc.months,
+				tc.policy,
+				got,
+				err,
+				tc.expected,
+			)
+		}
+	}
+
+	if _, err := jan31.AddMonths(1, datetime.ErrorOnOverflow); !errors.Is(
+		err,
+		datetime.ErrMonthOverflow,
+	) {
+		t.Errorf("Expected ErrMonthOverflow, got %v", err)
+	}
+
+	if _, err := jan31.AddMonths(1, datetime.OverflowPolicy(9)); err == nil {
+		t.Errorf("Expected error for unknown policy")
+	}
+}
+
+func TestAddYears(t *testing.T) {
+	leapDay := datetime.Date(2024, 2, 29, 0, 0, 0, 0)
+
+	tests := []struct {
+		policy   datetime.OverflowPolicy
+		expected datetime.Time
+	}{
+		{datetime.ClampToMonthEnd, datetime.Date(2025, 2, 28, 0, 0, 0, 0)},
+		{datetime.PreserveMonthEnd, datetime.Date(2025, 2, 28, 0, 0, 0, 0)},
+		{datetime.NormalizeOverflow, datetime.Date(2025, 3, 1, 0, 0, 0, 0)},
+	}
+
+	for _, tc := range tests {
+		got, err := leapDay.AddYears(1, tc.policy)
+		if err != nil || !got.Equal(tc.expected) {
+			t.Errorf(
+				"AddYears(1, %v) = %v, %v, want %v",
+				tc.policy,
+				got,
+				err,
+				tc.expected,
+			)
+		}
+	}
+
+	if _, err := leapDay.AddYears(1, datetime.ErrorOnOverflow); !errors.Is(
+		err,
+		datetime.ErrMonthOverflow,
+	) {
+		t.Errorf("Expected ErrMonthOverflow, got %v", err)
+	}
+
+	got, err := leapDay.AddYears(4, datetime.ErrorOnOverflow)
+	expected := datetime.Date(2028, 2, 29, 0, 0, 0, 0)
+	if err != nil || !got.Equal(expected) {
+		t.Errorf("AddYears(4) = %v, %v, want %v", got, err, expected)
+	}
+}
+
+func TestMonthlySchedule(t *testing.T) {
+	anchor := datetime.Date(2021, 1, 31, 9, 0, 0, 0)
+	schedule := datetime.NewMonthlySchedule(anchor, 1, datetime.ClampToMonthEnd)
+
+	expected := []datetime.Time{
+		datetime.Date(2021, 1, 31, 9, 0, 0, 0),
+		datetime.Date(2021, 2, 28, 9, 0, 0, 0),
+		datetime.Date(2021, 3, 31, 9, 0, 0, 0),
+		datetime.Date(2021, 4, 30, 9, 0, 0, 0),
+		datetime.Date(2021, 5, 31, 9, 0, 0, 0),
+	}
+
+	for n, e := range expected {
+		got, err := schedule.Occurrence(n)
+		if err != nil || !got.Equal(e) {
+			t.Errorf("Occurrence(%d) = %v, %v, want %v", n, got, err, e)
+		}
+	}
+
+	got, err := schedule.Between(
+		datetime.Date(2021, 2, 1, 0, 0, 0, 0),
+		datetime.Date(2021, 4, 30, 9, 0, 0, 0),
+	)
+	if err != nil || len(got) != 3 {
+		t.Fatalf("Between() = %v, %v, want 3 occurrences", got, err)
+	}
+
+	for i, e := range expected[1:4] {
+		if !got[i].Equal(e) {
+			t.Errorf("Between()[%d] = %v, want %v", i, got[i], e)
+		}
+	}
+}
+
+func TestMonthlyScheduleNext(t *testing.T) {
+	anchor := datetime.Date(2020, 11, 30, 0, 0, 0, 0)
+	schedule := datetime.NewMonthlySchedule(anchor, 3, datetime.PreserveMonthEnd)
+
+	tests := []struct {
+		after    datetime.Time
+		expected datetime.Time
+		index    int
+	}{
+		{datetime.Date(2019, 1, 1, 0, 0, 0, 0), anchor, 0},
+		{anchor, datetime.Date(2021, 2, 28, 0, 0, 0, 0), 1},
+		{
+			datetime.Date(2021, 2, 28, 0, 0, 0, 0),
+			datetime.Date(2021, 5, 31, 0, 0, 0, 0),
+			2,
+		},
+		{
+			datetime.Date(2023, 12, 1, 0, 0, 0, 0),
+			datetime.Date(2024, 2, 29, 0, 0, 0, 0),
+			13,
+		},
+	}
+
+	for _, tc := range tests {
+		got, n, err := schedule.Next(tc.after)
+		if err != nil || !got.Equal(tc.expected) || n != tc.index {
+			t.Errorf(
+				"Next(%v) = %v, %d, %v, want %v, %d",
+				tc.after,
+				got,
+				n,
+				err,
+				tc.expected,
+				tc.index,
+			)
+		}
+	}
+
+	invalid := datetime.NewMonthlySchedule(anchor, 0, datetime.ClampToMonthEnd)
+	if _, _, err := invalid.Next(anchor); err == nil {
+		t.Errorf("Expected error for non-positive interval")
+	}
+}
+
+func TestMonthlyScheduleErrorOnOverflow(t *testing.T) {
+	anchor := datetime.Date(2021, 1, 31, 9, 0, 0, 0)
+	schedule := datetime.NewMonthlySchedule(anchor, 1, datetime.ErrorOnOverflow)
+
+	if _, err := schedule.Occurrence(1); !errors.Is(err, datetime.ErrMonthOverflow) {
+		t.Errorf("Occurrence(1) error = %v, want ErrMonthOverflow", err)
+	}
+
+	// Occurrences on missing days are skipped
+	got, n, err := schedule.Next(anchor)
+	expected := datetime.Date(2021, 3, 31, 9, 0, 0, 0)
+	if err != nil || !got.Equal(expected) || n != 2 {
+		t.Errorf("Next(%v) = %v, %d, %v, want %v, 2", anchor, got, n, err, expected)
+	}
+
+	between, err := schedule.Between(anchor, datetime.Date(2021, 12, 31, 9, 0, 0, 0))
+	if err != nil {
+		t.Fatalf("Between() error = %v", err)
+	}
+
+	var months []datetime.Month
+	for _, occurrence := range between {
+		months = append(months, occurrence.Month())
+	}
+
+	expectedMonths := []datetime.Month{1, 3, 5, 7, 8, 10, 12}
+	if !slices.Equal(months, expectedMonths) {
+		t.Errorf("Between() months = %v, want %v", months, expectedMonths)
+	}
+
+	// Feb 29 only exists in leap years
+	leap := datetime.NewMonthlySchedule(
+		datetime.Date(2096, 2, 29, 0, 0, 0, 0), 12, datetime.ErrorOnOverflow,
+	)
+	got, n, err = leap.Next(datetime.Date(2096, 3, 1, 0, 0, 0, 0))
+	expected = datetime.Date(2104, 2, 29, 0, 0, 0, 0)
+	if err != nil || !got.Equal(expected) || n != 8 {
+		t.Errorf("Next() = %v, %d, %v, want %v, 8", got, n, err, expected)
+	}
+}
+
+func ExampleMonthlySchedule() {
+	anchor := datetime.Date(2021, 1, 31, 0, 0, 0, 0)
+	schedule := datetime.NewMonthlySchedule(anchor, 1, datetime.PreserveMonthEnd)
+
+	for n := range 4 {
+		dt, _ := schedule.Occurrence(n)
+		_, _ = fmt.Println(dt.Format(datetime.DateOnly))
+	}
+	// Output:
+	// 2021-01-31
+	// 2021-02-28
+	// 2021-03-31
+	// 2021-04-30
+}
@@ -0,0 +1,162 @@
+package datetime
+
+/*
+Instants that remember the UTC offset they were received with.
+
+Time is normalized to UTC, so "2021-08-09T21:37:03+05:30" and "2021-08-09T16:07:03Z"
+become the same value. OffsetTime keeps the offset next to the instant, so audit
+records can echo back exactly what an upstream sent. Comparison is by instant, two
+values with different offsets are Equal if they are the same instant, but == also
+compares the offset.
+*/
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"time"
+)
+
+// OffsetTime is an instant together with an offset from UTC
+type OffsetTime struct {
+	t Time
+	// Seconds east of UTC
+	offset int32
+}
+
+// NewOffsetTime returns the instant t at offset, offset is truncated to seconds
+func NewOffsetTime(t Time, offset Duration) OffsetTime {
+	return OffsetTime{t: t, offset: int32(offset / Seconds(1))}
+}
+
+// OffsetTimeIn returns the instant t with the offset of the zone at t
+func OffsetTimeIn(t Time, z Zone) OffsetTime {
+	_, offset := z.Offset(t)
+	return NewOffsetTime(t, offset)
+}
+
+/*
+ParseOffsetTime parses a time in RFC 3339 format and keeps its offset.
+
+"Z" is offset zero. Errors are either ErrInvalidSyntax or ErrOutOfRange.
+*/
+func ParseOffsetTime(s string) (OffsetTime, error) {
+	t, err := ParseRFC3339(s)
+	if err != nil {
+		return OffsetTime{}, err
+	}
+
+	// Offset was validated by ParseRFC3339
+	var offset int64
+	if last := s[len(s)-1]; last != 'Z' && last != 'z' {
+		offset, _ = parseOffset(s[len(s)-len("+00:00"):])
+	}
+
+	return NewOffsetTime(t, Seconds(offset)), nil
+}
+
+// Time returns the instant in UTC
+func (o OffsetTime) Time() Time {
+	return o.t
+}
+
+// Offset returns the offset from UTC
+func (o OffsetTime) Offset() Duration {
+	return Seconds(int64(o.offset))
+}
+
+// Local returns the wall time at the offset
+func (o OffsetTime) Local() LocalDateTime {
+	return LocalDateTimeOf(o.t.Add(o.Offset()))
+}
+
+// IsZero reports whether o is the zero value
+func (o OffsetTime) IsZero() bool {
+	return time.Time(o.t).IsZero() && o.offset == 0
+}
+
+// Compare returns -1, 0 or 1 when o is before, equal to or after u, ignoring offsets
+func (o OffsetTime) Compare(u OffsetTime) int {
+	return time.Time(o.t).Compare(time.Time(u.t))
+}
+
+// Equal reports whether o and u are the same instant, offsets may differ
+func (o OffsetTime) Equal(u OffsetTime) bool {
+	return o.t.Equal(u.t)
+}
+
+// Before reports whether o is before u
+func (o OffsetTime) Before(u OffsetTime) bool {
+	return o.t.Before(u.t)
+}
+
+// After reports whether o is after u
+func (o OffsetTime) After(u OffsetTime) bool {
+	return o.t.After(u.t)
+}
+
+// location returns a fixed time.Location with the offset
+func (o OffsetTime) location() *time.Location {
+	if o.offset == 0 {
+		return time.UTC
+	}
+	return time.FixedZone("", int(o.offset))
+}
+
+// Format formats the wall time at the offset, zone name is printed as the offset
+func (o OffsetTime) Format(format PrintFormat) string {
+	var buf [maxRFC3339Len + len("+00:00")]byte
+	return string(o.AppendFormat(buf[:0], format))
+}
+
+// AppendFormat is like Format but appends to dst and returns the extended buffer
+func (o OffsetTime) AppendFormat(dst []byte, format PrintFormat) []byte {
+	if o.offset == 0 {
+		return o.t.AppendFormat(dst, format)
+	}
+	return time.Time(o.t).In(o.location()).AppendFormat(dst, string(format))
+}
+
+// String returns the time in RFC 3339 format with nanoseconds and the original offset
+func (o OffsetTime) String() string {
+	return o.Format(RFC3339Nano)
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (o OffsetTime) MarshalText() ([]byte, error) {
+	return o.AppendFormat(nil, RFC3339Nano), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (o *OffsetTime) UnmarshalText(data []byte) error {
+	parsed, err := ParseOffsetTime(string(data))
+	if err != nil {
+		return fmt.Errorf("datetime: parsing time %q: %w", data, err)
+	}
+
+	*o = parsed
+	return nil
+}
+
+// Value implements driver.Valuer, the time is stored as RFC 3339 to keep the offset
+func (o OffsetTime) Value() (driver.Value, error) {
+	return o.String(), nil
+}
+
+// Scan implements sql.Scanner, NULL is scanned as the zero value
+func (o *OffsetTime) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*o = OffsetTime{}
+		return nil
+	case time.Time:
+		_, offset := v.Zone()
+		*o = NewOffsetTime(wallFromTime(v), Seconds(int64(offset)))
+		return nil
+	case string:
+		return o.UnmarshalText([]byte(v))
+	case []byte:
+		return o.UnmarshalText(v)
+	default:
+		return fmt.Errorf("datetime: cannot scan %T into OffsetTime", src)
+	}
+}
@@ -0,0 +1,176 @@
+package datetime_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ram-nad/go-utils/datetime"
+)
+
+func TestParseOffsetTime(t *testing.T) {
+	tests := []struct {
+		s        string
+		instant  datetime.Time
+		offset   datetime.Duration
+		expected string
+	}{
+		{
+			"2021-08-09T21:37:03+05:30",
+			datetime.Date(2021, 8, 9, 16, 7, 3, 0),
+			datetime.Minutes(330),
+			"2021-08-09T21:37:03+05:30",
+		},
+		{
+			"2021-08-09T11:07:03.5-05:00",
+			datetime.Date(2021, 8, 9, 16, 7, 3, 500_000_000),
+			-datetime.Hours(5),
+			"2021-08-09T11:07:03.5-05:00",
+		},
+		{
+			"2021-08-09T16:07:03z",
+			datetime.Date(2021, 8, 9, 16, 7, 3, 0),
+			0,
+			"2021-08-09T16:07:03Z",
+		},
+		{
+			"2021-08-09T16:07:03+00:00",
+			datetime.Date(2021, 8, 9, 16, 7, 3, 0),
+			0,
+			"2021-08-09T16:07:03Z",
+		},
+	}
+
+	for _, tc := range tests {
+		got, err := datetime.ParseOffsetTime(tc.s)
+		if err != nil || !got.Time().Equal(tc.instant) || got.Offset() != tc.offset {
+			t.Errorf(
+				"ParseOffsetTime(%q) = %v, %v, %v, want %v, %v",
+				tc.s,
+				got.Time(),
+				got.Offset(),
+				err,
+				tc.instant,
+				tc.offset,
+			)
+		}
+
+		if got.String() != tc.expected {
+			t.Errorf(
+				"ParseOffsetTime(%q).String() = %q, want %q",
+				tc.s,
+				got,
+				tc.expected,
+			)
+		}
+	}
+
+	invalid := []struct {
+		s   string
+		err error
+	}{
+		{"2021-08-09T16:07:03", datetime.ErrInvalidSyntax},
+		{"2021-08-09T16:07:03+0530", datetime.ErrInvalidSyntax},
+		{"2021-08-09T16:07:03+24:00", datetime.ErrOutOfRange},
+	}
+
+	for _, tc := range invalid {
+		if _, err := datetime.ParseOffsetTime(tc.s); !errors.Is(err, tc.err) {
+			t.Errorf("ParseOffsetTime(%q) = %v, want %v", tc.s, err, tc.err)
+		}
+	}
+}
+
+func TestOffsetTimeCompare(t *testing.T) {
+	instant := datetime.Date(2021, 8, 9, 16, 7, 3, 0)
+	ist := datetime.NewOffsetTime(instant, datetime.Minutes(330))
+	utc := datetime.NewOffsetTime(instant, 0)
+	later := datetime.NewOffsetTime(instant.Add(1), -datetime.Hours(5))
+
+	if !ist.Equal(utc) || ist.Compare(utc) != 0 || ist == utc {
+		t.Errorf("%v and %v should be the same instant, offsets differ", ist, utc)
+	}
+
+	if !ist.Before(later) || !later.After(utc) || later.Compare(ist) != 1 {
+		t.Errorf("%v should be after %v", later, ist)
+	}
+
+	if got := ist.Local(); got != datetime.NewLocalDateTime(2021, 8, 9, 21, 37, 3, 0) {
+		t.Errorf("Local() = %v, want 2021-08-09T21:37:03", got)
+	}
+
+	z, _ := datetime.LoadZone("America/New_York")
+	if got := datetime.OffsetTimeIn(instant, z); got.Offset() != -datetime.Hours(4) {
+		t.Errorf("OffsetTimeIn(New York) = %v, want offset -04:00", got)
+	}
+}
+
+func TestOffsetTimeFormat(t *testing.T) {
+	o := datetime.NewOffsetTime(
+		datetime.Date(2021, 8, 9, 16, 7, 3, 123_000_000),
+		-datetime.Minutes(150),
+	)
+
+	tests := []struct {
+		format   datetime.PrintFormat
+		expected string
+	}{
+		{datetime.RFC3339, "2021-08-09T13:37:03-02:30"},
+		{datetime.RFC3339Milli, "2021-08-09T13:37:03.123-02:30"},
+		{datetime.RFC1123Z, "Mon, 09 Aug 2021 13:37:03 -0230"},
+		{datetime.RFC1123, "Mon, 09 Aug 2021 13:37:03 -0230"},
+	}
+
+	for _, tc := range tests {
+		if got := o.Format(tc.format); got != tc.expected {
+			t.Errorf("Format(%q) = %q, want %q", tc.format, got, tc.expected)
+		}
+	}
+}
+
+func TestOffsetTimeMarshal(t *testing.T) {
+	type record struct {
+		Received datetime.OffsetTime `json:"received"`
+	}
+
+	data := []byte(`{"received":"2021-08-09T21:37:03.25+05:30"}`)
+
+	var r record
+	if err := json.Unmarshal(data, &r); err != nil {
+		t.Fatalf("json.Unmarshal() = %v", err)
+	}
+
+	out, err := json.Marshal(r)
+	if err != nil || string(out) != string(data) {
+		t.Errorf("json.Marshal() = %s, %v, want %s", out, err, data)
+	}
+
+	if v, _ := r.Received.Value(); v != "2021-08-09T21:37:03.25+05:30" {
+		t.Errorf("Value() = %v", v)
+	}
+
+	var scanned datetime.OffsetTime
+	src := time.Date(2021, 8, 9, 21, 37, 3, 0, time.FixedZone("IST", 19800))
+	if err := scanned.Scan(src); err != nil ||
+		scanned.String() != "2021-08-09T21:37:03+05:30" {
+		t.Errorf("Scan(%v) = %v, %v", src, scanned, err)
+	}
+
+	if err := scanned.Scan(nil); err != nil || !scanned.IsZero() {
+		t.Errorf("Scan(nil) = %v, %v", scanned, err)
+	}
+}
+
+func ExampleOffsetTime() {
+	received, _ := datetime.ParseOffsetTime("2021-08-09T21:37:03+05:30")
+
+	_, _ = fmt.Println(received.Time())
+	_, _ = fmt.Println(received)
+	_, _ = fmt.Println(received.Format(datetime.RFC1123Z))
+	// Output:
+	// Mon, 09 Aug 2021 16:07:03 UTC
+	// 2021-08-09T21:37:03+05:30
+	// Mon, 09 Aug 2021 21:37:03 +0530
+}
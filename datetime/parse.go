@@ -0,0 +1,177 @@
+package datetime
+
+/*
+Hand written parser for RFC 3339 timestamps.
+
+time.Parse is generic over layouts and shows up as a hotspot when parsing large
+volumes of timestamps. ParseRFC3339 only understands the RFC 3339 profile of
+ISO 8601 and does not allocate:
+
+	date-time  = YYYY "-" MM "-" DD ("T" / "t" / " ") hh ":" mm ":" ss [frac] offset
+	frac       = "." 1*9DIGIT
+	offset     = "Z" / "z" / ("+" / "-") hh ":" mm
+
+All fields are validated strictly: day must exist in the month, leap seconds
+(second 60) are rejected, offset hours must be below 24. The numeric offset is
+applied so the returned Time is in UTC.
+*/
+
+import (
+	"errors"
+	"unsafe"
+)
+
+var (
+	// ErrInvalidSyntax is returned when the input doesn't match the expected format
+	ErrInvalidSyntax = errors.New("datetime: invalid syntax")
+	// ErrOutOfRange is returned when a field of the input is out of its valid range
+	ErrOutOfRange = errors.New("datetime: field out of range")
+)
+
+// Minimum length of RFC 3339 input, "2006-01-02T15:04:05Z"
+const minRFC3339Len = 20
+
+// parseDigits parses exactly len(s) decimal digits
+func parseDigits(s string) (int, bool) {
+	v := 0
+	for i := range len(s) {
+		c := s[i] - '0'
+		if c > 9 { //nolint:mnd // decimal digits
+			return 0, false
+		}
+		v = v*10 + int(c) //nolint:mnd // decimal digits
+	}
+	return v, true
+}
+
+// parseFixed parses the fixed width "YYYY-MM-DDThh:mm:ss" prefix
+//
+//nolint:mnd // field offsets of RFC 3339
+func parseFixed(s string) (fields [6]int, err error) {
+	if s[4] != '-' || s[7] != '-' || s[13] != ':' || s[16] != ':' {
+		return fields, ErrInvalidSyntax
+	}
+
+	switch s[10] {
+	case 'T', 't', ' ':
+	default:
+		return fields, ErrInvalidSyntax
+	}
+
+	// Start index and width of year, month, day, hour, minute, second
+	spans := [6][2]int{{0, 4}, {5, 2}, {8, 2}, {11, 2}, {14, 2}, {17, 2}}
+
+	for i, span := range spans {
+		v, ok := parseDigits(s[span[0] : span[0]+span[1]])
+		if !ok {
+			return fields, ErrInvalidSyntax
+		}
+		fields[i] = v
+	}
+
+	return fields, nil
+}
+
+// parseFraction parses "." followed by 1 to 9 digits, returns nanoseconds and bytes consumed
+func parseFraction(s string) (nsec int, n int, err error) {
+	if len(s) == 0 || s[0] != '.' {
+		return 0, 0, nil
+	}
+
+	n = 1
+	for n < len(s) && s[n] >= '0' && s[n] <= '9' {
+		n++
+	}
+
+	digits := n - 1
+	if digits == 0 || digits > maxFracDigits {
+		return 0, 0, ErrInvalidSyntax
+	}
+
+	nsec, _ = parseDigits(s[1:n])
+	for range maxFracDigits - digits {
+		nsec *= 10 //nolint:mnd // decimal digits
+	}
+
+	return nsec, n, nil
+}
+
+// parseOffset parses "Z" or "±hh:mm", returns offset east of UTC in seconds
+//
+//nolint:mnd // field offsets of numeric offset
+func parseOffset(s string) (int64, error) {
+	if len(s) == 1 && (s[0] == 'Z' || s[0] == 'z') {
+		return 0, nil
+	}
+
+	if len(s) != len("+00:00") || s[3] != ':' {
+		return 0, ErrInvalidSyntax
+	}
+
+	sign := int64(1)
+	switch s[0] {
+	case '+':
+	case '-':
+		sign = -1
+	default:
+		return 0, ErrInvalidSyntax
+	}
+
+	hh, ok1 := parseDigits(s[1:3])
+	mm, ok2 := parseDigits(s[4:6])
+	if !ok1 || !ok2 {
+		return 0, ErrInvalidSyntax
+	}
+
+	if hh > 23 || mm > 59 {
+		return 0, ErrOutOfRange
+	}
+
+	return sign * int64(hh*secondsPerHour+mm*secondsPerMinute), nil
+}
+
+/*
+ParseRFC3339 parses an RFC 3339 timestamp and returns it in UTC.
+
+Fractional seconds up to nanosecond precision are supported. Errors are either
+ErrInvalidSyntax or ErrOutOfRange.
+*/
+//nolint:mnd // ranges of RFC 3339 fields
+func ParseRFC3339(s string) (Time, error) {
+	if len(s) < minRFC3339Len {
+		return Time{}, ErrInvalidSyntax
+	}
+
+	f, err := parseFixed(s)
+	if err != nil {
+		return Time{}, err
+	}
+
+	rest := s[19:]
+	nsec, n, err := parseFraction(rest)
+	if err != nil {
+		return Time{}, err
+	}
+
+	offset, err := parseOffset(rest[n:])
+	if err != nil {
+		return Time{}, err
+	}
+
+	year, month, day, hour, minute, second := int64(f[0]), f[1], f[2], f[3], f[4], f[5]
+	if month < 1 || month > 12 || day < 1 || day > daysIn(Month(month), year) ||
+		hour > 23 || minute > 59 || second > 59 {
+		return Time{}, ErrOutOfRange
+	}
+
+	secOfDay := hour*secondsPerHour + minute*secondsPerMinute + second
+	sec := daysFromCivil(year, month, day)*secondsPerDay + int64(secOfDay)
+
+	return Unix(sec-offset, int64(nsec)), nil
+}
+
+// ParseRFC3339Bytes is like ParseRFC3339 but parses the timestamp from bytes
+func ParseRFC3339Bytes(b []byte) (Time, error) {
+	//nolint:gosec // string is not used after this call returns
+	return ParseRFC3339(unsafe.String(unsafe.SliceData(b), len(b)))
+}
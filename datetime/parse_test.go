@@ -0,0 +1,239 @@
+package datetime_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ram-nad/go-utils/datetime"
+)
+
+func TestParseRFC3339(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected datetime.Time
+	}{
+		{"2021-01-01T00:00:00Z", datetime.Date(2021, 1, 1, 0, 0, 0, 0)},
+		{"2021-01-01t00:00:00z", datetime.Date(2021, 1, 1, 0, 0, 0, 0)},
+		{"2021-01-01 00:00:00Z", datetime.Date(2021, 1, 1, 0, 0, 0, 0)},
+		{"2021-01-01T00:00:01.000000023Z", datetime.Date(2021, 1, 1, 0, 0, 1, 23)},
+		{"2021-01-01T00:00:01.5Z", datetime.Date(2021, 1, 1, 0, 0, 1, 500_000_000)},
+		{
+			"2024-02-29T23:59:59.999+00:00",
+			datetime.Date(2024, 2, 29, 23, 59, 59, 999_000_000),
+		},
+		{"2021-01-01T05:30:00+05:30", datetime.Date(2021, 1, 1, 0, 0, 0, 0)},
+		{"2020-12-31T23:00:00-01:00", datetime.Date(2021, 1, 1, 0, 0, 0, 0)},
+		{"0000-01-01T00:00:00Z", datetime.Date(0, 1, 1, 0, 0, 0, 0)},
+		{
+			"9999-12-31T23:59:59.999999999Z",
+			datetime.Date(9999, 12, 31, 23, 59, 59, 999_999_999),
+		},
+		{"1969-12-31T23:59:59Z", datetime.Unix(-1, 0)},
+	}
+
+	for _, tc := range tests {
+		got, err := datetime.ParseRFC3339(tc.input)
+		if err != nil {
+			t.Errorf("ParseRFC3339(%q) returned error %v", tc.input, err)
+			continue
+		}
+
+		if !got.Equal(tc.expected) {
+			t.Errorf("ParseRFC3339(%q) = %v, want %v", tc.input, got, tc.expected)
+		}
+
+		got, err = datetime.ParseRFC3339Bytes([]byte(tc.input))
+		if err != nil || !got.Equal(tc.expected) {
+			t.Errorf(
+				"ParseRFC3339Bytes(%q) = %v, %v, want %v",
+				tc.input,
+				got,
+				err,
+				tc.expected,
+			)
+		}
+	}
+}
+
+func TestParseRFC3339Errors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected error
+	}{
+		{"", datetime.ErrInvalidSyntax},
+		{"2021-01-01", datetime.ErrInvalidSyntax},
+		{"2021-01-01T00:00:00", datetime.ErrInvalidSyntax},
+		{"2021-01-01X00:00:00Z", datetime.ErrInvalidSyntax},
+		{"2021/01/01T00:00:00Z", datetime.ErrInvalidSyntax},
+		{"2021-01-01T00:00:00.Z", datetime.ErrInvalidSyntax},
+		{"2021-01-01T00:00:00.1234567890Z", datetime.ErrInvalidSyntax},
+		{"2021-01-01T00:00:00+0530", datetime.ErrInvalidSyntax},
+		{"2021-01-01T00:00:00+05:3a", datetime.ErrInvalidSyntax},
+		{"2021-01-01T00:00:00Zjunk", datetime.ErrInvalidSyntax},
+		{"+021-01-01T00:00:00Z", datetime.ErrInvalidSyntax},
+		{"2021-13-01T00:00:00Z", datetime.ErrOutOfRange},
+		{"2021-00-01T00:00:00Z", datetime.ErrOutOfRange},
+		{"2021-02-29T00:00:00Z", datetime.ErrOutOfRange},
+		{"2021-04-31T00:00:00Z", datetime.ErrOutOfRange},
+		{"2021-01-00T00:00:00Z", datetime.ErrOutOfRange},
+		{"2021-01-01T24:00:00Z", datetime.ErrOutOfRange},
+		{"2021-01-01T00:60:00Z", datetime.ErrOutOfRange},
+		{"2021-01-01T00:00:60Z", datetime.ErrOutOfRange},
+		{"2021-01-01T00:00:00+24:00", datetime.ErrOutOfRange},
+		{"2021-01-01T00:00:00-00:60", datetime.ErrOutOfRange},
+	}
+
+	for _, tc := range tests {
+		if _, err := datetime.ParseRFC3339(tc.input); !errors.Is(err, tc.expected) {
+			t.Errorf("ParseRFC3339(%q) error = %v, want %v", tc.input, err, tc.expected)
+		}
+	}
+}
+
+func TestParseRFC3339RoundTrip(t *testing.T) {
+	dt := datetime.Date(2021, 7, 14, 9, 41, 3, 120_450_000)
+
+	for _, format := range rfc3339Formats() {
+		parsed, err := datetime.ParseRFC3339(dt.Format(format))
+		if err != nil {
+			t.Fatalf("ParseRFC3339(%q) returned error %v", dt.Format(format), err)
+		}
+
+		expected := dt.Truncate(datetime.Seconds(1))
+		switch format {
+		case datetime.RFC3339Milli:
+			expected = dt.Truncate(datetime.Milliseconds(1))
+		case datetime.RFC3339Micro:
+			expected = dt.Truncate(datetime.Microseconds(1))
+		case datetime.RFC3339Nano:
+			expected = dt
+		}
+
+		if !parsed.Equal(expected) {
+			t.Errorf("Round trip of %q = %v, want %v", format, parsed, expected)
+		}
+	}
+}
+
+func TestParseRFC3339Allocations(t *testing.T) {
+	input := []byte("2021-01-02T03:04:05.123456789+05:30")
+
+	allocs := testing.AllocsPerRun(100, func() {
+		_, _ = datetime.ParseRFC3339Bytes(input)
+	})
+
+	if allocs != 0 {
+		t.Errorf("ParseRFC3339Bytes allocated %v times, want 0", allocs)
+	}
+}
+
+// canonicalRFC3339 converts extensions accepted by ParseRFC3339 to form accepted by time.Parse
+func canonicalRFC3339(s string) string {
+	if len(s) < 20 {
+		return s
+	}
+
+	b := []byte(s)
+	if b[10] == 't' || b[10] == ' ' {
+		b[10] = 'T'
+	}
+
+	if b[len(b)-1] == 'z' {
+		b[len(b)-1] = 'Z'
+	}
+
+	return string(b)
+}
+
+// FuzzParseRFC3339 checks that everything accepted is accepted by time.Parse as well
+func FuzzParseRFC3339(f *testing.F) {
+	f.Add("2021-01-01T00:00:00Z")
+	f.Add("2021-01-01T00:00:01.000000023Z")
+	f.Add("2024-02-29T23:59:59.999+05:30")
+	f.Add("2021-01-01t00:00:00z")
+	f.Add("2021-01-01 00:00:00-23:59")
+	f.Add("2021-02-29T00:00:00Z")
+	f.Add("2021-01-01T00:00:00.1234567890Z")
+
+	f.Fuzz(func(t *testing.T, s string) {
+		got, err := datetime.ParseRFC3339(s)
+		if err != nil {
+			return
+		}
+
+		expected, stdErr := time.Parse(time.RFC3339Nano, canonicalRFC3339(s))
+		if stdErr != nil {
+			t.Fatalf("ParseRFC3339(%q) = %v, but time.Parse failed: %v", s, got, stdErr)
+		}
+
+		if !time.Time(got).Equal(expected) {
+			t.Fatalf("ParseRFC3339(%q) = %v, time.Parse = %v", s, got, expected)
+		}
+	})
+}
+
+// FuzzParseRFC3339Formatted checks that timestamps formatted by time.Format are parsed
+func FuzzParseRFC3339Formatted(f *testing.F) {
+	f.Add(int64(0), int64(0), int16(0))
+	f.Add(int64(1609459200), int64(23), int16(330))
+	f.Add(int64(-62167219200), int64(999_999_999), int16(-1439))
+	f.Add(int64(253402300799), int64(120_000_000), int16(60))
+
+	f.Fuzz(func(t *testing.T, sec int64, nsec int64, offsetMinutes int16) {
+		minSec := datetime.Date(0, 1, 2, 0, 0, 0, 0).Unix()
+		maxSec := datetime.Date(9999, 12, 30, 0, 0, 0, 0).Unix()
+		if sec < minSec || sec > maxSec || nsec < 0 || nsec >= int64(time.Second) {
+			return
+		}
+
+		if offsetMinutes <= -24*60 || offsetMinutes >= 24*60 {
+			return
+		}
+
+		zone := time.FixedZone("", int(offsetMinutes)*60)
+		tm := time.Unix(sec, nsec).In(zone)
+
+		for _, layout := range []string{time.RFC3339, time.RFC3339Nano} {
+			s := tm.Format(layout)
+			expected, _ := time.Parse(layout, s)
+
+			got, err := datetime.ParseRFC3339(s)
+			if err != nil {
+				t.Fatalf("ParseRFC3339(%q) failed with %v", s, err)
+			}
+
+			if !time.Time(got).Equal(expected) {
+				t.Fatalf("ParseRFC3339(%q) = %v, time.Parse = %v", s, got, expected)
+			}
+		}
+	})
+}
+
+func BenchmarkParseRFC3339(b *testing.B) {
+	for b.Loop() {
+		_, err := datetime.ParseRFC3339("2021-01-02T03:04:05.123456789+05:30")
+		if err != nil {
+			b.Fatalf("Error: %s", err.Error())
+		}
+	}
+}
+
+func BenchmarkParseRFC3339Bytes(b *testing.B) {
+	input := []byte("2021-01-02T03:04:05.123456789+05:30")
+	for b.Loop() {
+		_, err := datetime.ParseRFC3339Bytes(input)
+		if err != nil {
+			b.Fatalf("Error: %s", err.Error())
+		}
+	}
+}
+
+func BenchmarkTimeParseRFC3339(b *testing.B) {
+	for b.Loop() {
+		_, err := time.Parse(time.RFC3339Nano, "2021-01-02T03:04:05.123456789+05:30")
+		if err != nil {
+			b.Fatalf("Error: %s", err.Error())
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package datetime
+
+/*
+Boundaries of calendar periods and ISO 8601 week dates.
+
+All periods are computed in UTC. EndOf returns the last representable instant of
+the period (start of the next period minus one nanosecond), so a time t is in the
+period when !t.Before(start) && !t.After(end).
+
+Weeks start on Monday as defined by ISO 8601, use StartOfWeek and EndOfWeek for
+other conventions.
+*/
+
+import (
+	"fmt"
+	"time"
+)
+
+// CalendarUnit is a period of the calendar
+type CalendarUnit int
+
+const (
+	UnitDay CalendarUnit = iota
+	UnitWeek
+	UnitMonth
+	UnitQuarter
+	UnitYear
+)
+
+const (
+	daysPerWeek      = 7
+	monthsPerQuarter = 3
+	isoWeekAnchor    = 4 // January 4th is always in ISO week 1
+)
+
+// String returns name of the unit
+func (u CalendarUnit) String() string {
+	switch u {
+	case UnitDay:
+		return "day"
+	case UnitWeek:
+		return "week"
+	case UnitMonth:
+		return "month"
+	case UnitQuarter:
+		return "quarter"
+	case UnitYear:
+		return "year"
+	default:
+		return fmt.Sprintf("CalendarUnit(%d)", int(u))
+	}
+}
+
+/*
+StartOf returns the first instant of the period of the given unit containing t.
+
+Weeks start on Monday. StartOf panics for an unknown unit.
+*/
+func (t Time) StartOf(unit CalendarUnit) Time {
+	year, month, day := t.Year(), int(t.Month()), t.Day()
+
+	switch unit {
+	case UnitDay:
+		return Date(year, month, day, 0, 0, 0, 0)
+	case UnitWeek:
+		return t.StartOfWeek(time.Monday)
+	case UnitMonth:
+		return Date(year, month, 1, 0, 0, 0, 0)
+	case UnitQuarter:
+		return Date(year, (t.Quarter()-1)*monthsPerQuarter+1, 1, 0, 0, 0, 0)
+	case UnitYear:
+		return Date(year, 1, 1, 0, 0, 0, 0)
+	default:
+		panic("datetime: unknown calendar unit " + unit.String())
+	}
+}
+
+/*
+EndOf returns the last instant of the period of the given unit containing t.
+
+Weeks start on Monday. EndOf panics for an unknown unit.
+*/
+func (t Time) EndOf(unit CalendarUnit) Time {
+	start := t.StartOf(unit)
+
+	var next Time
+	switch unit {
+	case UnitDay:
+		next = start.AddDate(0, 0, 1)
+	case UnitWeek:
+		next = start.AddDate(0, 0, daysPerWeek)
+	case UnitMonth:
+		next = start.AddDate(0, 1, 0)
+	case UnitQuarter:
+		next = start.AddDate(0, monthsPerQuarter, 0)
+	default: // UnitYear, unknown units panic in StartOf
+		next = start.AddDate(1, 0, 0)
+	}
+
+	return next.Add(-1)
+}
+
+// StartOfWeek returns the first instant of the week containing t, weeks begin on weekStart
+func (t Time) StartOfWeek(weekStart Weekday) Time {
+	offset := (int(t.Weekday()) - int(weekStart) + daysPerWeek) % daysPerWeek
+	return t.StartOf(UnitDay).AddDate(0, 0, -offset)
+}
+
+// EndOfWeek returns the last instant of the week containing t, weeks begin on weekStart
+func (t Time) EndOfWeek(weekStart Weekday) Time {
+	return t.StartOfWeek(weekStart).AddDate(0, 0, daysPerWeek).Add(-1)
+}
+
+// Quarter returns quarter of the year (1 to 4) of the time
+func (t Time) Quarter() int {
+	return (int(t.Month())-1)/monthsPerQuarter + 1
+}
+
+/*
+ISOWeek returns the ISO 8601 year and week number of the time.
+
+Week ranges from 1 to 53. Jan 01 to Jan 03 may belong to week 52 or 53 of the
+previous year, and Dec 29 to Dec 31 may belong to week 1 of the next year.
+*/
+func (t Time) ISOWeek() (year, week int) {
+	return time.Time(t).ISOWeek()
+}
+
+// ISOWeekday returns ISO 8601 day of the week, from 1 (Monday) to 7 (Sunday)
+func (t Time) ISOWeekday() int {
+	return isoWeekday(t.Weekday())
+}
+
+func isoWeekday(d Weekday) int {
+	if d == time.Sunday {
+		return daysPerWeek
+	}
+	return int(d)
+}
+
+/*
+ISOWeekDate returns midnight of the given ISO 8601 week date.
+
+Values outside their usual ranges are normalized, like in Date. For example week 0
+is the last week of the previous ISO year.
+*/
+func ISOWeekDate(year, week int, weekday Weekday) Time {
+	jan4 := Date(year, 1, isoWeekAnchor, 0, 0, 0, 0)
+	week1 := jan4.AddDate(0, 0, 1-jan4.ISOWeekday())
+
+	return week1.AddDate(0, 0, (week-1)*daysPerWeek+isoWeekday(weekday)-1)
+}
+
+// ISOWeeksInYear returns number of weeks (52 or 53) in the ISO 8601 year
+func ISOWeeksInYear(year int) int {
+	// Dec 28th is always in the last week of the ISO year
+	_, week := Date(year, 12, 28, 0, 0, 0, 0).ISOWeek() //nolint:mnd // December 28th
+	return week
+}
@@ -0,0 +1,226 @@
+package datetime_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ram-nad/go-utils/datetime"
+)
+
+func TestStartOfEndOf(t *testing.T) {
+	// Thursday
+	dt := datetime.Date(2021, 8, 19, 13, 45, 12, 345)
+
+	tests := []struct {
+		unit  datetime.CalendarUnit
+		start datetime.Time
+		end   datetime.Time
+	}{
+		{
+			datetime.UnitDay,
+			datetime.Date(2021, 8, 19, 0, 0, 0, 0),
+			datetime.Date(2021, 8, 19, 23, 59, 59, 999_999_999),
+		},
+		{
+			datetime.UnitWeek,
+			datetime.Date(2021, 8, 16, 0, 0, 0, 0),
+			datetime.Date(2021, 8, 22, 23, 59, 59, 999_999_999),
+		},
+		{
+			datetime.UnitMonth,
+			datetime.Date(2021, 8, 1, 0, 0, 0, 0),
+			datetime.Date(2021, 8, 31, 23, 59, 59, 999_999_999),
+		},
+		{
+			datetime.UnitQuarter,
+			datetime.Date(2021, 7, 1, 0, 0, 0, 0),
+			datetime.Date(2021, 9, 30, 23, 59, 59, 999_999_999),
+		},
+		{
+			datetime.UnitYear,
+			datetime.Date(2021, 1, 1, 0, 0, 0, 0),
+			datetime.Date(2021, 12, 31, 23, 59, 59, 999_999_999),
+		},
+	}
+
+	for _, tc := range tests {
+		if got := dt.StartOf(tc.unit); !got.Equal(tc.start) {
+			t.Errorf("StartOf(%v) = %v, want %v", tc.unit, got, tc.start)
+		}
+
+		if got := dt.EndOf(tc.unit); !got.Equal(tc.end) {
+			t.Errorf("EndOf(%v) = %v, want %v", tc.unit, got.ISOStringNano(), tc.end)
+		}
+	}
+
+	leap := datetime.Date(2024, 2, 10, 0, 0, 0, 0)
+	expected := datetime.Date(2024, 2, 29, 23, 59, 59, 999_999_999)
+	if got := leap.EndOf(datetime.UnitMonth); !got.Equal(expected) {
+		t.Errorf("EndOf(month) = %v, want %v", got, expected)
+	}
+}
+
+func TestStartOfUnknownUnit(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected StartOf to panic for unknown unit")
+		}
+	}()
+
+	datetime.Now().StartOf(datetime.CalendarUnit(42))
+}
+
+func TestStartOfWeek(t *testing.T) {
+	// Sunday
+	dt := datetime.Date(2021, 8, 22, 10, 0, 0, 0)
+
+	tests := []struct {
+		weekStart datetime.Weekday
+		start     datetime.Time
+	}{
+		{time.Monday, datetime.Date(2021, 8, 16, 0, 0, 0, 0)},
+		{time.Sunday, datetime.Date(2021, 8, 22, 0, 0, 0, 0)},
+		{time.Saturday, datetime.Date(2021, 8, 21, 0, 0, 0, 0)},
+	}
+
+	for _, tc := range tests {
+		if got := dt.StartOfWeek(tc.weekStart); !got.Equal(tc.start) {
+			t.Errorf("StartOfWeek(%v) = %v, want %v", tc.weekStart, got, tc.start)
+		}
+
+		end := tc.start.AddDate(0, 0, 7).Add(-1)
+		if got := dt.EndOfWeek(tc.weekStart); !got.Equal(end) {
+			t.Errorf("EndOfWeek(%v) = %v, want %v", tc.weekStart, got, end)
+		}
+	}
+}
+
+func TestQuarter(t *testing.T) {
+	for month := 1; month <= 12; month++ {
+		expected := (month + 2) / 3
+		if got := datetime.Date(2021, month, 1, 0, 0, 0, 0).Quarter(); got != expected {
+			t.Errorf("Quarter() of month %d = %d, want %d", month, got, expected)
+		}
+	}
+}
+
+func TestISOWeek(t *testing.T) {
+	tests := []struct {
+		dt      datetime.Time
+		year    int
+		week    int
+		weekday int
+	}{
+		{datetime.Date(2021, 1, 1, 0, 0, 0, 0), 2020, 53, 5},
+		{datetime.Date(2021, 1, 4, 0, 0, 0, 0), 2021, 1, 1},
+		{datetime.Date(2019, 12, 30, 0, 0, 0, 0), 2020, 1, 1},
+		{datetime.Date(2021, 8, 22, 0, 0, 0, 0), 2021, 33, 7},
+	}
+
+	for _, tc := range tests {
+		year, week := tc.dt.ISOWeek()
+		if year != tc.year || week != tc.week {
+			t.Errorf(
+				"ISOWeek() of %v = %d-W%d, want %d-W%d",
+				tc.dt,
+				year,
+				week,
+				tc.year,
+				tc.week,
+			)
+		}
+
+		if got := tc.dt.ISOWeekday(); got != tc.weekday {
+			t.Errorf("ISOWeekday() of %v = %d, want %d", tc.dt, got, tc.weekday)
+		}
+
+		if got := datetime.ISOWeekDate(year, week, tc.dt.Weekday()); !got.Equal(tc.dt) {
+			t.Errorf(
+				"ISOWeekDate(%d, %d, %v) = %v, want %v",
+				year,
+				week,
+				tc.dt.Weekday(),
+				got,
+				tc.dt,
+			)
+		}
+	}
+}
+
+func TestISOWeekDateRoundTrip(t *testing.T) {
+	dt := datetime.Date(1999, 1, 1, 0, 0, 0, 0)
+	end := datetime.Date(2031, 1, 1, 0, 0, 0, 0)
+
+	for dt.Before(end) {
+		year, week := dt.ISOWeek()
+		if got := datetime.ISOWeekDate(year, week, dt.Weekday()); !got.Equal(dt) {
+			t.Fatalf(
+				"ISOWeekDate(%d, %d, %v) = %v, want %v",
+				year,
+				week,
+				dt.Weekday(),
+				got,
+				dt,
+			)
+		}
+		dt = dt.AddDate(0, 0, 1)
+	}
+}
+
+func TestISOWeekDateNormalization(t *testing.T) {
+	expected := datetime.Date(2020, 12, 28, 0, 0, 0, 0)
+	if got := datetime.ISOWeekDate(2021, 0, time.Monday); !got.Equal(expected) {
+		t.Errorf("ISOWeekDate(2021, 0, Monday) = %v, want %v", got, expected)
+	}
+}
+
+func TestISOWeeksInYear(t *testing.T) {
+	tests := map[int]int{2015: 53, 2019: 52, 2020: 53, 2021: 52, 2026: 53}
+
+	for year, expected := range tests {
+		if got := datetime.ISOWeeksInYear(year); got != expected {
+			t.Errorf("ISOWeeksInYear(%d) = %d, want %d", year, got, expected)
+		}
+	}
+}
+
+func TestLeapYearAndDaysInMonth(t *testing.T) {
+	leapYears := map[int]bool{
+		1900: false,
+		2000: true,
+		2020: true,
+		2021: false,
+		2100: false,
+	}
+
+	for year, expected := range leapYears {
+		if got := datetime.IsLeapYear(year); got != expected {
+			t.Errorf("IsLeapYear(%d) = %v, want %v", year, got, expected)
+		}
+	}
+
+	for year := 1999; year <= 2001; year++ {
+		for month := time.January; month <= time.December; month++ {
+			expected := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+			if got := datetime.DaysInMonth(year, month); got != expected {
+				t.Errorf(
+					"DaysInMonth(%d, %v) = %d, want %d",
+					year,
+					month,
+					got,
+					expected,
+				)
+			}
+		}
+	}
+}
+
+func ExampleTime_StartOf() {
+	dt := datetime.Date(2021, 8, 19, 13, 45, 12, 0)
+	_, _ = fmt.Println(dt.StartOf(datetime.UnitQuarter).ISOString())
+	_, _ = fmt.Println(dt.EndOf(datetime.UnitMonth).ISOStringNano())
+	// Output:
+	// 2021-07-01T00:00:00Z
+	// 2021-08-31T23:59:59.999999999Z
+}
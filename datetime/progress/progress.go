@@ -0,0 +1,218 @@
+/*
+Package progress tracks completed units of a job and estimates when it will be done.
+
+A Tracker is told how many units were completed at which ClockTime. Units are folded
+into samples at least an Interval long, so bursts of small updates don't make rates
+jumpy. Reading a Snapshot folds the time since the last sample too, so rates drop
+while the job is stalled.
+
+Two rates are reported, both in units per second:
+
+	Instant  the rate of the last sample
+	Rate     the rate smoothed by the Model, used to estimate the time remaining
+
+The Model is one of:
+
+	Exponential  moving average with time constant Window, recent samples count most
+	Sliding      rate over the last Window
+	Mean         rate since the start
+
+For example to log progress of a batch job:
+
+	tracker := progress.New(int64(len(rows)), datetime.NowClock(), progress.Config{
+		Unit: "rows",
+	})
+	for i, row := range rows {
+		process(row)
+		tracker.Add(1, datetime.NowClock())
+		if i%1000 == 0 {
+			log.Print(tracker.Snapshot(datetime.NowClock()))
+		}
+	}
+*/
+package progress
+
+import (
+	"math"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ram-nad/go-utils/datetime"
+)
+
+// Model is how the smoothed rate is computed from samples
+type Model int
+
+// Config are options of a Tracker, zero values mean defaults
+type Config struct {
+	// Unit is the name of units in status lines, like "rows" or "files"
+	Unit string
+	// Model is the smoothing model of the rate, Exponential by default
+	Model Model
+	// Window is the time constant of Exponential and the length of Sliding,
+	// DefaultWindow by default
+	Window datetime.Duration
+	// Interval is the shortest length of a sample, DefaultInterval by default
+	Interval datetime.Duration
+}
+
+// Tracker records progress of a job, it is safe for concurrent use
+type Tracker struct {
+	mu     sync.Mutex
+	config Config
+	start  datetime.ClockTime
+	total  int64
+	done   int64
+	// last is the end of the last sample, pending are units completed after it
+	last    datetime.ClockTime
+	pending int64
+	instant float64
+	rate    float64
+	sampled bool
+	// samples are ends of samples within Window, used by Sliding
+	samples []sample
+}
+
+// sample is the number of units completed at a time
+type sample struct {
+	at   datetime.ClockTime
+	done int64
+}
+
+const (
+	// Exponential weighs samples by exp(-age / Window)
+	Exponential Model = iota
+	// Sliding is the rate of units completed within the last Window
+	Sliding
+	// Mean is the rate of all units completed since the start
+	Mean
+)
+
+const (
+	// DefaultWindow is the default Window of rate models
+	DefaultWindow = 30 * datetime.Duration(time.Second)
+	// DefaultInterval is the default shortest length of samples
+	DefaultInterval = datetime.Duration(time.Second)
+)
+
+// String returns name of the model
+func (m Model) String() string {
+	switch m {
+	case Exponential:
+		return "exponential"
+	case Sliding:
+		return "sliding"
+	case Mean:
+		return "mean"
+	default:
+		return "unknown"
+	}
+}
+
+/*
+New creates a tracker of a job of total units started at start. Total of zero means it
+is unknown, there is no estimate of the time remaining then.
+
+New panics if total, Window or Interval is negative, or Model is unknown.
+*/
+func New(total int64, start datetime.ClockTime, config Config) *Tracker {
+	if total < 0 {
+		panic("progress: negative total for New")
+	}
+
+	if config.Window < 0 || config.Interval < 0 {
+		panic("progress: negative window or interval for New")
+	}
+
+	if config.Model < Exponential || config.Model > Mean {
+		panic("progress: unknown model for New")
+	}
+
+	if config.Window == 0 {
+		config.Window = DefaultWindow
+	}
+
+	if config.Interval == 0 {
+		config.Interval = DefaultInterval
+	}
+
+	return &Tracker{
+		config:  config,
+		start:   start,
+		total:   total,
+		last:    start,
+		samples: []sample{{at: start}},
+	}
+}
+
+// Add records n units completed at now
+func (t *Tracker) Add(n int64, now datetime.ClockTime) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.done += n
+	t.pending += n
+	t.fold(now)
+}
+
+// SetTotal changes the total number of units, like when more work is found
+func (t *Tracker) SetTotal(total int64) {
+	if total < 0 {
+		panic("progress: negative total for SetTotal")
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.total = total
+}
+
+// Done returns the number of units completed
+func (t *Tracker) Done() int64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.done
+}
+
+// fold ends a sample at now if it is at least Interval long
+func (t *Tracker) fold(now datetime.ClockTime) {
+	elapsed := now.Sub(t.last)
+	if elapsed < t.config.Interval {
+		return
+	}
+
+	seconds := time.Duration(elapsed).Seconds()
+	t.instant = float64(t.pending) / seconds
+	t.last, t.pending = now, 0
+
+	switch t.config.Model {
+	case Exponential:
+		if t.sampled {
+			alpha := 1 - math.Exp(-float64(elapsed)/float64(t.config.Window))
+			t.rate += alpha * (t.instant - t.rate)
+		} else {
+			t.rate = t.instant
+		}
+	case Sliding:
+		t.samples = append(t.samples, sample{at: now, done: t.done})
+
+		// Keep the last sample ending at or before the start of the window
+		from := now.Add(-t.config.Window)
+		i := 0
+		for i+1 < len(t.samples) && t.samples[i+1].at <= from {
+			i++
+		}
+		t.samples = slices.Delete(t.samples, 0, i)
+
+		first := t.samples[0]
+		t.rate = float64(t.done-first.done) / time.Duration(now.Sub(first.at)).Seconds()
+	case Mean:
+		t.rate = float64(t.done) / time.Duration(now.Sub(t.start)).Seconds()
+	default:
+		panic("progress: unknown model")
+	}
+
+	t.sampled = true
+}
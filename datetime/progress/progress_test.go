@@ -0,0 +1,179 @@
+package progress_test
+
+import (
+	"math"
+	"sync"
+	"testing"
+
+	"github.com/ram-nad/go-utils/datetime"
+	"github.com/ram-nad/go-utils/datetime/progress"
+)
+
+const start = datetime.ClockTime(1_000_000_000)
+
+// at returns the time seconds after start
+func at(seconds int64) datetime.ClockTime {
+	return start.Add(datetime.Seconds(seconds))
+}
+
+// near reports whether got is within a millionth of expected
+func near(got, expected float64) bool {
+	return math.Abs(got-expected) <= 1e-6*math.Max(math.Abs(expected), 1)
+}
+
+func TestExponential(t *testing.T) {
+	tracker := progress.New(100, start, progress.Config{Window: datetime.Seconds(10)})
+
+	tracker.Add(10, at(1))
+	if s := tracker.Snapshot(at(1)); s.Instant != 10 || s.Rate != 10 {
+		t.Errorf("Snapshot() = %+v after first sample, want rates 10", s)
+	}
+
+	tracker.Add(30, at(2))
+	s := tracker.Snapshot(at(2))
+
+	rate := 10 + (1-math.Exp(-0.1))*20
+	if s.Instant != 30 || !near(s.Rate, rate) {
+		t.Errorf("Instant, Rate = %v, %v, want 30, %v", s.Instant, s.Rate, rate)
+	}
+
+	remaining := datetime.Duration(60 / rate * 1e9)
+	if s.Done != 40 || (s.Remaining-remaining).Abs() > 1 {
+		t.Errorf(
+			"Done, Remaining = %v, %v, want 40, %v", s.Done, s.Remaining, remaining,
+		)
+	}
+}
+
+func TestSamplesLastInterval(t *testing.T) {
+	tracker := progress.New(0, start, progress.Config{})
+
+	// Units are folded into a sample once an Interval passed
+	tracker.Add(5, start.Add(datetime.Milliseconds(100)))
+	tracker.Add(5, start.Add(datetime.Milliseconds(500)))
+	if s := tracker.Snapshot(start.Add(datetime.Milliseconds(900))); s.Rate != 0 {
+		t.Errorf("Rate() = %v before the first sample, want 0", s.Rate)
+	}
+
+	tracker.Add(2, start.Add(datetime.Milliseconds(1500)))
+	if s := tracker.Snapshot(start.Add(datetime.Milliseconds(1500))); s.Instant != 8 {
+		t.Errorf("Instant = %v, want 8", s.Instant)
+	}
+
+	if tracker.Done() != 12 {
+		t.Errorf("Done() = %v, want 12", tracker.Done())
+	}
+}
+
+func TestSliding(t *testing.T) {
+	tracker := progress.New(1000, start, progress.Config{
+		Model:  progress.Sliding,
+		Window: datetime.Seconds(10),
+	})
+
+	for i := range int64(10) {
+		tracker.Add(10, at(i+1))
+	}
+
+	if s := tracker.Snapshot(at(10)); s.Rate != 10 {
+		t.Errorf("Rate = %v, want 10", s.Rate)
+	}
+
+	// Only the last 10 seconds count
+	tracker.Add(40, at(15))
+	if s := tracker.Snapshot(at(15)); s.Rate != 9 || s.Instant != 8 {
+		t.Errorf("Rate, Instant = %v, %v, want 9, 8", s.Rate, s.Instant)
+	}
+
+	// Rates drop while stalled
+	s := tracker.Snapshot(at(30))
+	if s.Rate != 0 || s.Instant != 0 || s.Remaining != 0 {
+		t.Errorf("Snapshot() = %+v when stalled, want zero rates and Remaining", s)
+	}
+}
+
+func TestMean(t *testing.T) {
+	tracker := progress.New(100, start, progress.Config{Model: progress.Mean})
+
+	tracker.Add(30, at(3))
+	s := tracker.Snapshot(at(6))
+	if s.Rate != 5 || s.Remaining != datetime.Seconds(14) {
+		t.Errorf("Rate, Remaining = %v, %v, want 5, 14s", s.Rate, s.Remaining)
+	}
+
+	tracker.SetTotal(130)
+	if s := tracker.Snapshot(at(6)); s.Remaining != datetime.Seconds(20) {
+		t.Errorf("Remaining = %v after SetTotal, want 20s", s.Remaining)
+	}
+}
+
+func TestModelString(t *testing.T) {
+	tests := []struct {
+		model    progress.Model
+		expected string
+	}{
+		{progress.Exponential, "exponential"},
+		{progress.Sliding, "sliding"},
+		{progress.Mean, "mean"},
+		{progress.Model(7), "unknown"},
+	}
+
+	for _, tc := range tests {
+		if got := tc.model.String(); got != tc.expected {
+			t.Errorf("String() = %q, want %q", got, tc.expected)
+		}
+	}
+}
+
+func TestNewPanics(t *testing.T) {
+	tests := []struct {
+		name   string
+		total  int64
+		config progress.Config
+	}{
+		{"negative total", -1, progress.Config{}},
+		{"negative window", 10, progress.Config{Window: -1}},
+		{"negative interval", 10, progress.Config{Interval: -1}},
+		{"unknown model", 10, progress.Config{Model: progress.Model(7)}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("New() didn't panic")
+				}
+			}()
+
+			progress.New(tc.total, start, tc.config)
+		})
+	}
+}
+
+func TestConcurrentAdds(t *testing.T) {
+	tracker := progress.New(8000, start, progress.Config{})
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Go(func() {
+			for i := range int64(1000) {
+				tracker.Add(1, start.Add(datetime.Milliseconds(i)))
+			}
+		})
+	}
+	wg.Wait()
+
+	if s := tracker.Snapshot(at(1)); s.Done != 8000 || !s.Finished() {
+		t.Errorf("Snapshot() = %+v, want 8000 done", s)
+	}
+}
+
+func BenchmarkAdd(b *testing.B) {
+	tracker := progress.New(0, start, progress.Config{Model: progress.Sliding})
+	now := start
+
+	for b.Loop() {
+		tracker.Add(1, now)
+		now = now.Add(datetime.Microseconds(1))
+	}
+}
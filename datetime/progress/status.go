@@ -0,0 +1,153 @@
+package progress
+
+/*
+Status lines are meant for logs and terminals, so numbers are short rather than exact:
+rates have three significant digits with SI prefixes, durations are rounded to seconds
+and show at most two units.
+
+	420/1000 rows (42%), 12.3 rows/s, elapsed 34s, ETA 47s
+	420 rows, 12.3 rows/s, elapsed 34s
+	1000/1000 rows (100%), 29.4 rows/s, done in 1m25s
+*/
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ram-nad/go-utils/datetime"
+)
+
+// Snapshot is the progress of a Tracker at one time, rates are per second
+type Snapshot struct {
+	Unit string
+	// Total is zero when unknown
+	Total   int64
+	Done    int64
+	Elapsed datetime.Duration
+	Instant float64
+	Rate    float64
+	// Remaining is the estimated time left, zero if Total is unknown or Rate is zero
+	Remaining datetime.Duration
+}
+
+// Snapshot returns progress at now, it ends a sample if Interval passed since the last
+func (t *Tracker) Snapshot(now datetime.ClockTime) Snapshot {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.fold(now)
+
+	s := Snapshot{
+		Unit:    t.config.Unit,
+		Total:   t.total,
+		Done:    t.done,
+		Elapsed: now.Sub(t.start),
+		Instant: t.instant,
+		Rate:    t.rate,
+	}
+
+	if left := t.total - t.done; left > 0 && t.rate > 0 {
+		remaining := float64(left) / t.rate * float64(time.Second)
+		s.Remaining = math.MaxInt64
+		if remaining < math.MaxInt64 {
+			s.Remaining = datetime.Duration(remaining)
+		}
+	}
+
+	return s
+}
+
+// Fraction returns the part of Total done between 0 and 1, zero if Total is unknown
+func (s Snapshot) Fraction() float64 {
+	if s.Total == 0 {
+		return 0
+	}
+
+	return min(float64(s.Done)/float64(s.Total), 1)
+}
+
+// Finished reports whether all of the known Total is done
+func (s Snapshot) Finished() bool {
+	return s.Total > 0 && s.Done >= s.Total
+}
+
+// String returns a status line like "420/1000 (42%), 12.3/s, elapsed 34s, ETA 47s"
+func (s Snapshot) String() string {
+	unit := ""
+	if s.Unit != "" {
+		unit = " " + s.Unit
+	}
+
+	var sb strings.Builder
+	sb.WriteString(strconv.FormatInt(s.Done, 10))
+	if s.Total > 0 {
+		// Floored so that 100% means done
+		percent := int64(math.Floor(100 * s.Fraction()))
+		sb.WriteByte('/')
+		sb.WriteString(strconv.FormatInt(s.Total, 10))
+		sb.WriteString(unit)
+		sb.WriteString(" (")
+		sb.WriteString(strconv.FormatInt(percent, 10))
+		sb.WriteString("%)")
+	} else {
+		sb.WriteString(unit)
+	}
+
+	sb.WriteString(", ")
+	sb.WriteString(humanizeRate(s.Rate))
+	sb.WriteString(unit)
+	sb.WriteString("/s")
+
+	if s.Finished() {
+		sb.WriteString(", done in ")
+		sb.WriteString(humanizeDuration(s.Elapsed))
+		return sb.String()
+	}
+
+	sb.WriteString(", elapsed ")
+	sb.WriteString(humanizeDuration(s.Elapsed))
+	if s.Total > 0 {
+		sb.WriteString(", ETA ")
+		if s.Remaining > 0 {
+			sb.WriteString(humanizeDuration(s.Remaining))
+		} else {
+			sb.WriteString("unknown")
+		}
+	}
+
+	return sb.String()
+}
+
+// humanizeRate formats r with three significant digits and an SI prefix
+func humanizeRate(r float64) string {
+	if r < 1 {
+		return strconv.FormatFloat(r, 'f', 2, 64) //nolint:mnd // hundredths
+	}
+
+	prefixes := "kMGTPE"
+	prefix := ""
+	for i := 0; r >= 999.5 && i < len(prefixes); i++ {
+		r /= 1000
+		prefix = prefixes[i : i+1]
+	}
+
+	return strconv.FormatFloat(r, 'g', 3, 64) + prefix //nolint:mnd // digits
+}
+
+// humanizeDuration formats d rounded to seconds with at most two units, like 1h02m
+func humanizeDuration(d datetime.Duration) string {
+	seconds := d.Round(datetime.Seconds(1)).Seconds()
+
+	//nolint:mnd // seconds in minutes and hours
+	switch {
+	case seconds >= 3600:
+		return fmt.Sprintf("%dh%02dm", seconds/3600, seconds%3600/60)
+	case seconds >= 60:
+		return fmt.Sprintf("%dm%02ds", seconds/60, seconds%60)
+	default:
+		return fmt.Sprintf("%ds", seconds)
+	}
+}
@@ -0,0 +1,106 @@
+package progress_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ram-nad/go-utils/datetime"
+	"github.com/ram-nad/go-utils/datetime/progress"
+)
+
+func TestSnapshotString(t *testing.T) {
+	tests := []struct {
+		snapshot progress.Snapshot
+		expected string
+	}{
+		{
+			progress.Snapshot{
+				Unit:      "rows",
+				Total:     1000,
+				Done:      420,
+				Elapsed:   datetime.Seconds(34),
+				Rate:      12.34,
+				Remaining: datetime.Milliseconds(47_400),
+			},
+			"420/1000 rows (42%), 12.3 rows/s, elapsed 34s, ETA 47s",
+		},
+		{
+			progress.Snapshot{Done: 420, Elapsed: datetime.Seconds(3723), Rate: 1234},
+			"420, 1.23k/s, elapsed 1h02m",
+		},
+		{
+			progress.Snapshot{
+				Total:   1000,
+				Done:    1000,
+				Elapsed: datetime.Seconds(85),
+				Rate:    29.4,
+			},
+			"1000/1000 (100%), 29.4/s, done in 1m25s",
+		},
+		{
+			progress.Snapshot{
+				Total:     1000,
+				Done:      999,
+				Elapsed:   datetime.Milliseconds(59_600),
+				Rate:      0.5,
+				Remaining: datetime.Seconds(2),
+			},
+			"999/1000 (99%), 0.50/s, elapsed 1m00s, ETA 2s",
+		},
+		{
+			progress.Snapshot{Total: 10, Done: 3},
+			"3/10 (30%), 0.00/s, elapsed 0s, ETA unknown",
+		},
+		{
+			progress.Snapshot{Unit: "B", Done: 1 << 40, Rate: 999.7},
+			"1099511627776 B, 1k B/s, elapsed 0s",
+		},
+		{
+			progress.Snapshot{Done: 1, Rate: 2.5e9},
+			"1, 2.5G/s, elapsed 0s",
+		},
+	}
+
+	for _, tc := range tests {
+		if got := tc.snapshot.String(); got != tc.expected {
+			t.Errorf("String() = %q, want %q", got, tc.expected)
+		}
+	}
+}
+
+func TestSnapshotFraction(t *testing.T) {
+	tests := []struct {
+		snapshot progress.Snapshot
+		fraction float64
+		finished bool
+	}{
+		{progress.Snapshot{Done: 5}, 0, false},
+		{progress.Snapshot{Total: 8, Done: 2}, 0.25, false},
+		{progress.Snapshot{Total: 8, Done: 8}, 1, true},
+		{progress.Snapshot{Total: 8, Done: 9}, 1, true},
+	}
+
+	for _, tc := range tests {
+		s := tc.snapshot
+		if s.Fraction() != tc.fraction || s.Finished() != tc.finished {
+			t.Errorf(
+				"Fraction(), Finished() = %v, %v of %+v, want %v, %v",
+				s.Fraction(), s.Finished(), s, tc.fraction, tc.finished,
+			)
+		}
+	}
+}
+
+func ExampleTracker() {
+	tracker := progress.New(600, start, progress.Config{
+		Unit:  "files",
+		Model: progress.Sliding,
+	})
+
+	for i := range int64(20) {
+		tracker.Add(5, start.Add(datetime.Seconds(i+1)))
+	}
+
+	_, _ = fmt.Println(tracker.Snapshot(start.Add(datetime.Seconds(20))))
+	// Output: 100/600 files (16%), 5 files/s, elapsed 20s, ETA 1m40s
+}
@@ -0,0 +1,515 @@
+package datetime
+
+/*
+Parsing of relative times written in natural language.
+
+ParseRelative understands phrases like "yesterday 3pm", "next friday", "in 2 hours",
+"2 weeks ago" and "end of month", relative to a reference time in a time zone. The
+grammar, with words of the English vocabulary:
+
+	phrase  = "now"
+	        | "in" amount | amount "ago" | amount "from now"
+	        | edge [dir] unit                  end of month, start of next week
+	        | dir unit                         next week, last year (an interval)
+	        | day [clock] | clock [day]        tomorrow 9am, 15:30 yesterday
+	day     = "today" | "yesterday" | "tomorrow" | [dir] weekday
+	clock   = hour ("am" | "pm") | hour ":" minute ["am" | "pm"] | "noon" | "midnight"
+	amount  = number unit {number unit}        2 hours 30 minutes, a week
+	dir     = "next" | "last" | "this"
+	edge    = "start" | "beginning" | "end"
+
+Words are case-insensitive, "at", "of", "the", "and" and commas are ignored. A day
+without a clock, like "tomorrow" or "next friday", and dir unit phrases denote a span
+and are returned as an Interval. Everything else is an instant.
+
+Days, weeks, months and years move the wall clock in the zone, so "in 1 day" keeps the
+time of day across daylight saving time changes, while hours, minutes and seconds are
+exact. "next friday" is the first Friday after today, "last friday" the last one before
+today and "friday" or "this friday" is today or the next Friday. Weeks start on Monday.
+"end of month" is the last nanosecond of the month, like EndOf.
+
+Other languages can be supported by creating a Vocabulary. Words of a vocabulary can
+be phrases of up to three words, like "day after tomorrow".
+*/
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Interval is the span of time [Start, End)
+type Interval struct {
+	Start Time
+	End   Time
+}
+
+// Relative is a parsed relative time, either an instant or an interval
+type Relative struct {
+	// Time is the instant, or start of the interval
+	Time Time
+	// Interval is set when IsInterval is true
+	Interval   Interval
+	IsInterval bool
+}
+
+// WordKind is the meaning of a word in a Vocabulary
+type WordKind int
+
+// Word is an entry of a Vocabulary
+type Word struct {
+	Kind WordKind
+	// Value is days from today for WordDay, the Weekday for WordWeekday, 1, -1 or 0
+	// for next, last and this WordDirection, the number for WordNumber, minutes since
+	// midnight for WordClock and hours to add, 0 or 12, for WordMeridiem
+	Value int
+	// Duration is the length of a WordDurationUnit
+	Duration Duration
+	// Unit is the unit of a WordCalendarUnit
+	Unit CalendarUnit
+}
+
+// Vocabulary maps words of a language to their meaning in relative times
+type Vocabulary struct {
+	// Words maps lower case words and phrases of up to three words
+	Words map[string]Word
+}
+
+const (
+	// WordFiller is ignored, like "at" and "the"
+	WordFiller WordKind = iota
+	// WordNow is the reference time
+	WordNow
+	// WordDay is a day relative to today, like "tomorrow"
+	WordDay
+	// WordWeekday is name of a weekday
+	WordWeekday
+	// WordDirection selects the next, last or current day or unit
+	WordDirection
+	// WordNumber is a number, like "two" or "a"
+	WordNumber
+	// WordDurationUnit is a unit of exact length, like "hour"
+	WordDurationUnit
+	// WordCalendarUnit is a unit of the calendar, like "day" or "month"
+	WordCalendarUnit
+	// WordClock is a time of day, like "noon"
+	WordClock
+	// WordMeridiem is "am" or "pm"
+	WordMeridiem
+	// WordIn starts an amount in the future, like "in"
+	WordIn
+	// WordAgo follows an amount in the past, like "ago"
+	WordAgo
+	// WordLater follows an amount in the future, like "from now"
+	WordLater
+	// WordStart is the start of a unit, like "beginning"
+	WordStart
+	// WordEnd is the end of a unit
+	WordEnd
+)
+
+const (
+	// Longest phrase matched as a single word
+	maxPhraseWords = 3
+	minutesPerHour = 60
+	hoursPerHalf   = 12
+)
+
+// relativeParser parses words of a phrase
+type relativeParser struct {
+	input string
+	words []Word
+	pos   int
+	ref   Time
+	zone  Zone
+	// today is the date of the reference time in the zone
+	today LocalDate
+}
+
+// ParseRelative parses a relative time in English, see Vocabulary.ParseRelative
+func ParseRelative(s string, ref Time, zone Zone) (Relative, error) {
+	return englishVocabulary().ParseRelative(s, ref, zone)
+}
+
+/*
+ParseRelative parses a relative time phrase with words of the vocabulary. Dates are
+relative to the date of ref in the zone.
+
+Errors wrap ErrInvalidSyntax.
+*/
+func (v *Vocabulary) ParseRelative(s string, ref Time, zone Zone) (Relative, error) {
+	words, err := v.words(s)
+	if err != nil {
+		return Relative{}, err
+	}
+
+	p := relativeParser{
+		input: s,
+		words: words,
+		ref:   ref,
+		zone:  zone,
+		today: ref.LocalIn(zone).Date,
+	}
+
+	r, err := p.parse()
+	if err == nil && p.pos < len(p.words) {
+		err = p.error()
+	}
+
+	return r, err
+}
+
+// words splits s into words of the vocabulary, fillers are removed
+func (v *Vocabulary) words(s string) ([]Word, error) {
+	tokens := splitRelative(s)
+
+	var words []Word
+	for i := 0; i < len(tokens); {
+		word, n, err := v.match(tokens[i:])
+		if err != nil {
+			return nil, fmt.Errorf(
+				"%w: unknown word %q in %q",
+				ErrInvalidSyntax,
+				tokens[i],
+				s,
+			)
+		}
+
+		if word.Kind != WordFiller {
+			words = append(words, word)
+		}
+		i += n
+	}
+
+	return words, nil
+}
+
+// match returns the word at the start of tokens and number of tokens it spans
+func (v *Vocabulary) match(tokens []string) (Word, int, error) {
+	for n := min(len(tokens), maxPhraseWords); n > 0; n-- {
+		if word, ok := v.Words[strings.Join(tokens[:n], " ")]; ok {
+			return word, n, nil
+		}
+	}
+
+	token := tokens[0]
+	if hour, minute, ok := strings.Cut(token, ":"); ok {
+		h, err1 := strconv.Atoi(hour)
+		m, err2 := strconv.Atoi(minute)
+		if err1 != nil || err2 != nil || len(minute) != 2 || h > 23 || m > 59 {
+			return Word{}, 0, ErrInvalidSyntax
+		}
+		return Word{Kind: WordClock, Value: h*minutesPerHour + m}, 1, nil
+	}
+
+	n, err := strconv.Atoi(token)
+	if err != nil || n < 0 {
+		return Word{}, 0, ErrInvalidSyntax
+	}
+
+	return Word{Kind: WordNumber, Value: n}, 1, nil
+}
+
+// splitRelative splits s at spaces and commas, and between digits and letters
+func splitRelative(s string) []string {
+	var tokens []string
+
+	for _, field := range strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return unicode.IsSpace(r) || r == ','
+	}) {
+		start := 0
+		for i, r := range field {
+			if i > start && isClockRune(r) != isClockRune(rune(field[i-1])) {
+				tokens = append(tokens, field[start:i])
+				start = i
+			}
+		}
+		tokens = append(tokens, field[start:])
+	}
+
+	return tokens
+}
+
+// isClockRune reports whether r is part of a number or a clock like "15:04"
+func isClockRune(r rune) bool {
+	return r == ':' || r >= '0' && r <= '9'
+}
+
+func (p *relativeParser) error() error {
+	return fmt.Errorf("%w: %q is not a relative time", ErrInvalidSyntax, p.input)
+}
+
+// peek returns kind of the next word, WordFiller at the end
+func (p *relativeParser) peek(offset int) WordKind {
+	if p.pos+offset >= len(p.words) {
+		return WordFiller
+	}
+	return p.words[p.pos+offset].Kind
+}
+
+// next returns the next word and moves past it
+func (p *relativeParser) next() Word {
+	w := p.words[p.pos]
+	p.pos++
+	return w
+}
+
+func (p *relativeParser) parse() (Relative, error) {
+	switch p.peek(0) {
+	case WordNow:
+		p.next()
+		return Relative{Time: p.ref}, nil
+	case WordIn:
+		p.next()
+		return p.parseAmount(1)
+	case WordNumber:
+		if kind := p.peek(1); kind == WordDurationUnit || kind == WordCalendarUnit {
+			return p.parseAmount(0)
+		}
+		return p.parseDayAndClock()
+	case WordStart, WordEnd:
+		return p.parseEdge()
+	case WordDirection:
+		if p.peek(1) == WordCalendarUnit {
+			return p.parseUnitInterval()
+		}
+		return p.parseDayAndClock()
+	case WordDay, WordWeekday, WordClock:
+		return p.parseDayAndClock()
+	default:
+		return Relative{}, p.error()
+	}
+}
+
+/*
+parseAmount parses an amount like "2 hours 30 minutes". With sign 0 the amount must be
+followed by a word that says if it is in the past or the future.
+*/
+func (p *relativeParser) parseAmount(sign int) (Relative, error) {
+	var (
+		days, months int
+		exact        Duration
+	)
+
+	if p.peek(0) != WordNumber {
+		return Relative{}, p.error()
+	}
+
+	for p.peek(0) == WordNumber {
+		n := p.next().Value
+		if p.peek(0) != WordDurationUnit && p.peek(0) != WordCalendarUnit {
+			return Relative{}, p.error()
+		}
+
+		unit := p.next()
+		if unit.Kind == WordDurationUnit {
+			exact += Duration(n) * unit.Duration
+			continue
+		}
+
+		d, m := calendarUnitLength(unit.Unit)
+		days += n * d
+		months += n * m
+	}
+
+	if sign == 0 {
+		switch p.peek(0) {
+		case WordAgo:
+			sign = -1
+		case WordLater:
+			sign = 1
+		default:
+			return Relative{}, p.error()
+		}
+		p.next()
+	}
+
+	// Calendar units move the wall clock, the rest is exact
+	wall := p.ref.LocalIn(p.zone)
+	date, err := wall.Date.AddMonths(sign*months, ClampToMonthEnd)
+	if err != nil {
+		return Relative{}, err
+	}
+
+	wall.Date = date.AddDays(sign * days)
+	t, err := p.instant(wall)
+	if err != nil {
+		return Relative{}, err
+	}
+
+	return Relative{Time: t.Add(Duration(sign) * exact)}, nil
+}
+
+// calendarUnitLength returns length of the unit in days and months
+//
+//nolint:mnd // months in a quarter and a year
+func calendarUnitLength(unit CalendarUnit) (int, int) {
+	switch unit {
+	case UnitWeek:
+		return daysPerWeek, 0
+	case UnitMonth:
+		return 0, 1
+	case UnitQuarter:
+		return 0, 3
+	case UnitYear:
+		return 0, 12
+	default:
+		return 1, 0
+	}
+}
+
+// parseEdge parses "start of [dir] unit" and "end of [dir] unit"
+func (p *relativeParser) parseEdge() (Relative, error) {
+	end := p.next().Kind == WordEnd
+
+	direction := 0
+	if p.peek(0) == WordDirection {
+		direction = p.next().Value
+	}
+
+	if p.peek(0) != WordCalendarUnit {
+		return Relative{}, p.error()
+	}
+
+	interval, err := p.unitInterval(p.next().Unit, direction)
+	if end {
+		return Relative{Time: interval.End.Add(-1)}, err
+	}
+	return Relative{Time: interval.Start}, err
+}
+
+// parseUnitInterval parses "dir unit", like "next week"
+func (p *relativeParser) parseUnitInterval() (Relative, error) {
+	direction := p.next().Value
+	interval, err := p.unitInterval(p.next().Unit, direction)
+	return Relative{Time: interval.Start, Interval: interval, IsInterval: true}, err
+}
+
+// unitInterval returns the calendar unit containing today, moved by n units
+func (p *relativeParser) unitInterval(unit CalendarUnit, n int) (Interval, error) {
+	days, months := calendarUnitLength(unit)
+
+	start := LocalDateOf(p.today.At(TimeOfDay{}).StartOf(unit))
+	start = NewLocalDate(start.Year, int(start.Month)+n*months, start.Day+n*days)
+	end := NewLocalDate(start.Year, int(start.Month)+months, start.Day+days)
+
+	return p.dayInterval(start, end)
+}
+
+// dayInterval returns the interval from midnight of start to midnight of end
+func (p *relativeParser) dayInterval(start, end LocalDate) (Interval, error) {
+	from, err := p.instant(LocalDateTime{Date: start})
+	if err != nil {
+		return Interval{}, err
+	}
+
+	to, err := p.instant(LocalDateTime{Date: end})
+	return Interval{Start: from, End: to}, err
+}
+
+// instant returns the instant of the wall time in the zone
+func (p *relativeParser) instant(wall LocalDateTime) (Time, error) {
+	return wall.In(p.zone, ShiftForward, PreferEarlier)
+}
+
+// parseDayAndClock parses a day, a clock or both in either order
+func (p *relativeParser) parseDayAndClock() (Relative, error) {
+	date, hasDate := p.parseDay()
+
+	tod, hasClock, err := p.parseClock()
+	if err != nil {
+		return Relative{}, err
+	}
+
+	if !hasDate {
+		date, hasDate = p.parseDay()
+	}
+
+	switch {
+	case hasDate && !hasClock:
+		interval, err := p.dayInterval(date, date.AddDays(1))
+		return Relative{Time: interval.Start, Interval: interval, IsInterval: true}, err
+	case !hasDate && !hasClock:
+		return Relative{}, p.error()
+	case !hasDate:
+		date = p.today
+	}
+
+	t, err := p.instant(LocalDateTime{Date: date, Time: tod})
+	return Relative{Time: t}, err
+}
+
+// parseDay parses "today", "yesterday", "tomorrow" or "[dir] weekday"
+func (p *relativeParser) parseDay() (LocalDate, bool) {
+	if p.peek(0) == WordDay {
+		return p.today.AddDays(p.next().Value), true
+	}
+
+	direction, weekday := 0, p.peek(0)
+	if weekday == WordDirection {
+		weekday = p.peek(1)
+	}
+
+	if weekday != WordWeekday {
+		return LocalDate{}, false
+	}
+
+	if p.peek(0) == WordDirection {
+		direction = p.next().Value
+	}
+
+	target := Weekday(p.next().Value)
+	days := (int(target) - int(p.today.Weekday()) + daysPerWeek) % daysPerWeek
+
+	switch {
+	case direction > 0 && days == 0:
+		days = daysPerWeek
+	case direction < 0:
+		days -= daysPerWeek
+	}
+
+	return p.today.AddDays(days), true
+}
+
+// parseClock parses a time of day, like "3pm", "15:30" or "noon"
+func (p *relativeParser) parseClock() (TimeOfDay, bool, error) {
+	minutes := 0
+	switch p.peek(0) {
+	case WordClock:
+		minutes = p.next().Value
+	case WordNumber:
+		// A number alone is only a clock with am or pm
+		if p.peek(1) != WordMeridiem {
+			return TimeOfDay{}, false, p.error()
+		}
+		minutes = p.next().Value * minutesPerHour
+	default:
+		return TimeOfDay{}, false, nil
+	}
+
+	if p.peek(0) == WordMeridiem {
+		hour := minutes / minutesPerHour
+		if hour < 1 || hour > hoursPerHalf {
+			return TimeOfDay{}, false, p.error()
+		}
+		minutes += (p.next().Value - hour/hoursPerHalf*hoursPerHalf) * minutesPerHour
+	}
+
+	return TimeOfDayAt(Minutes(int64(minutes))), true, nil
+}
+
+// Contains reports whether t is in the interval
+func (i Interval) Contains(t Time) bool {
+	return !t.Before(i.Start) && t.Before(i.End)
+}
+
+// Duration returns length of the interval
+func (i Interval) Duration() Duration {
+	return i.End.Sub(i.Start)
+}
+
+// String returns the interval as "[start, end)" in RFC 3339 format
+func (i Interval) String() string {
+	return "[" + i.Start.ISOStringNano() + ", " + i.End.ISOStringNano() + ")"
+}
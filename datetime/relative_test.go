@@ -0,0 +1,241 @@
+package datetime_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ram-nad/go-utils/datetime"
+)
+
+func TestParseRelative(t *testing.T) {
+	z := mustLoadZone(t, "America/New_York")
+	// Wednesday, 10:20:30 EDT
+	ref := datetime.Date(2021, 8, 11, 14, 20, 30, 0)
+
+	tests := []struct {
+		s        string
+		expected datetime.Time
+	}{
+		{"now", ref},
+		{"yesterday 3pm", datetime.Date(2021, 8, 10, 19, 0, 0, 0)},
+		{"3PM yesterday", datetime.Date(2021, 8, 10, 19, 0, 0, 0)},
+		{"tomorrow at noon", datetime.Date(2021, 8, 12, 16, 0, 0, 0)},
+		{"12am tomorrow", datetime.Date(2021, 8, 12, 4, 0, 0, 0)},
+		{"12pm", datetime.Date(2021, 8, 11, 16, 0, 0, 0)},
+		{"midnight", datetime.Date(2021, 8, 11, 4, 0, 0, 0)},
+		{"15:30", datetime.Date(2021, 8, 11, 19, 30, 0, 0)},
+		{"next Friday 9:30am", datetime.Date(2021, 8, 13, 13, 30, 0, 0)},
+		{"last wed, 11pm", datetime.Date(2021, 8, 5, 3, 0, 0, 0)},
+		{"in 2 hours", datetime.Date(2021, 8, 11, 16, 20, 30, 0)},
+		{"in 90 min", datetime.Date(2021, 8, 11, 15, 50, 30, 0)},
+		{"in a day and 2 hours", datetime.Date(2021, 8, 12, 16, 20, 30, 0)},
+		{"two days from now", datetime.Date(2021, 8, 13, 14, 20, 30, 0)},
+		{"2 weeks ago", datetime.Date(2021, 7, 28, 14, 20, 30, 0)},
+		{"1h 30m ago", datetime.Date(2021, 8, 11, 12, 50, 30, 0)},
+		// Wall clock moves from EDT to EST
+		{"in 3 months", datetime.Date(2021, 11, 11, 15, 20, 30, 0)},
+		{"a year ago", datetime.Date(2020, 8, 11, 14, 20, 30, 0)},
+		{"end of month", datetime.Date(2021, 9, 1, 3, 59, 59, 999_999_999)},
+		{"start of next week", datetime.Date(2021, 8, 16, 4, 0, 0, 0)},
+		{"beginning of the year", datetime.Date(2021, 1, 1, 5, 0, 0, 0)},
+		{"end of last quarter", datetime.Date(2021, 7, 1, 3, 59, 59, 999_999_999)},
+	}
+
+	for _, tc := range tests {
+		got, err := datetime.ParseRelative(tc.s, ref, z)
+		if err != nil || got.IsInterval || !got.Time.Equal(tc.expected) {
+			t.Errorf(
+				"ParseRelative(%q) = %+v, %v, want %v",
+				tc.s,
+				got,
+				err,
+				tc.expected,
+			)
+		}
+	}
+}
+
+func TestParseRelativeInterval(t *testing.T) {
+	z := mustLoadZone(t, "America/New_York")
+	ref := datetime.Date(2021, 8, 11, 14, 20, 30, 0)
+
+	// Local midnight of a day in August
+	day := func(d int) datetime.Time { return datetime.Date(2021, 8, d, 4, 0, 0, 0) }
+
+	tests := []struct {
+		s          string
+		start, end datetime.Time
+	}{
+		{"today", day(11), day(12)},
+		{"tomorrow", day(12), day(13)},
+		{"day after tomorrow", day(13), day(14)},
+		{"friday", day(13), day(14)},
+		{"next friday", day(13), day(14)},
+		{"last friday", day(6), day(7)},
+		{"wednesday", day(11), day(12)},
+		{"this wednesday", day(11), day(12)},
+		{"next wednesday", day(18), day(19)},
+		{"last wednesday", day(4), day(5)},
+		{"next week", day(16), day(23)},
+		{"last month", datetime.Date(2021, 7, 1, 4, 0, 0, 0), day(1)},
+		{
+			"this year",
+			datetime.Date(2021, 1, 1, 5, 0, 0, 0),
+			datetime.Date(2022, 1, 1, 5, 0, 0, 0),
+		},
+	}
+
+	for _, tc := range tests {
+		got, err := datetime.ParseRelative(tc.s, ref, z)
+		expected := datetime.Interval{Start: tc.start, End: tc.end}
+		if err != nil || !got.IsInterval || got.Interval != expected ||
+			got.Time != expected.Start {
+			t.Errorf("ParseRelative(%q) = %+v, %v, want %v", tc.s, got, err, expected)
+		}
+	}
+}
+
+func TestParseRelativeDST(t *testing.T) {
+	z := mustLoadZone(t, "America/New_York")
+	// Saturday noon EST, clocks move forward on Sunday
+	ref := datetime.Date(2021, 3, 13, 17, 0, 0, 0)
+
+	tests := []struct {
+		s        string
+		expected datetime.Time
+	}{
+		{"in 1 day", datetime.Date(2021, 3, 14, 16, 0, 0, 0)},
+		{"in 24 hours", datetime.Date(2021, 3, 14, 17, 0, 0, 0)},
+		// 2:30 doesn't exist and is shifted forward to 3:30 EDT
+		{"tomorrow 2:30am", datetime.Date(2021, 3, 14, 7, 30, 0, 0)},
+	}
+
+	for _, tc := range tests {
+		got, err := datetime.ParseRelative(tc.s, ref, z)
+		if err != nil || !got.Time.Equal(tc.expected) {
+			t.Errorf(
+				"ParseRelative(%q) = %+v, %v, want %v",
+				tc.s,
+				got,
+				err,
+				tc.expected,
+			)
+		}
+	}
+
+	// Day is 23 hours long
+	got, _ := datetime.ParseRelative("tomorrow", ref, z)
+	if got.Interval.Duration() != datetime.Hours(23) {
+		t.Errorf("ParseRelative(tomorrow) = %v, want 23 hours", got.Interval)
+	}
+}
+
+func TestParseRelativeErrors(t *testing.T) {
+	ref := datetime.Date(2021, 8, 11, 14, 20, 30, 0)
+
+	for _, s := range []string{
+		"",
+		"someday",
+		"next",
+		"in",
+		"2 hours",
+		"in 2",
+		"3",
+		"13pm",
+		"0am",
+		"25:00",
+		"3:5",
+		"tomorrow yesterday",
+		"now 3pm",
+		"end of friday",
+		"next week 3pm",
+		"2 hours ago tomorrow",
+	} {
+		if _, err := datetime.ParseRelative(s, ref, datetime.Zone{}); !errors.Is(
+			err,
+			datetime.ErrInvalidSyntax,
+		) {
+			t.Errorf("ParseRelative(%q) = %v, want ErrInvalidSyntax", s, err)
+		}
+	}
+}
+
+func TestVocabulary(t *testing.T) {
+	german := &datetime.Vocabulary{Words: map[string]datetime.Word{
+		"morgen":     {Kind: datetime.WordDay, Value: 1},
+		"übermorgen": {Kind: datetime.WordDay, Value: 2},
+		"nächsten":   {Kind: datetime.WordDirection, Value: 1},
+		"freitag":    {Kind: datetime.WordWeekday, Value: int(time.Friday)},
+		"in":         {Kind: datetime.WordIn},
+		"um":         {Kind: datetime.WordFiller},
+		"uhr":        {Kind: datetime.WordFiller},
+		"stunden":    {Kind: datetime.WordDurationUnit, Duration: datetime.Hours(1)},
+		"vor":        {Kind: datetime.WordFiller},
+	}}
+
+	z := mustLoadZone(t, "Europe/Berlin")
+	ref := datetime.Date(2021, 8, 11, 8, 0, 0, 0)
+
+	tests := []struct {
+		s        string
+		expected datetime.Time
+	}{
+		{"in 2 Stunden", datetime.Date(2021, 8, 11, 10, 0, 0, 0)},
+		{"Übermorgen um 15:00 Uhr", datetime.Date(2021, 8, 13, 13, 0, 0, 0)},
+		{"nächsten Freitag 9:00", datetime.Date(2021, 8, 13, 7, 0, 0, 0)},
+	}
+
+	for _, tc := range tests {
+		got, err := german.ParseRelative(tc.s, ref, z)
+		if err != nil || !got.Time.Equal(tc.expected) {
+			t.Errorf(
+				"ParseRelative(%q) = %+v, %v, want %v",
+				tc.s,
+				got,
+				err,
+				tc.expected,
+			)
+		}
+	}
+}
+
+func TestEnglishVocabularyCopy(t *testing.T) {
+	ref := datetime.Date(2021, 8, 11, 14, 20, 30, 0)
+
+	// Changes to a copy don't reach ParseRelative or other copies
+	v := datetime.EnglishVocabulary()
+	delete(v.Words, "tomorrow")
+	v.Words["morgen"] = datetime.Word{Kind: datetime.WordDay, Value: 1}
+
+	if _, err := v.ParseRelative("tomorrow", ref, datetime.Zone{}); err == nil {
+		t.Errorf("ParseRelative(tomorrow) with a changed copy succeeded")
+	}
+
+	if _, err := datetime.ParseRelative("tomorrow", ref, datetime.Zone{}); err != nil {
+		t.Errorf("ParseRelative(tomorrow) = %v after changing a copy", err)
+	}
+
+	if _, ok := datetime.EnglishVocabulary().Words["morgen"]; ok {
+		t.Errorf("EnglishVocabulary() has a word added to another copy")
+	}
+}
+
+func ExampleParseRelative() {
+	z, _ := datetime.LoadZone("Europe/London")
+	ref := datetime.Date(2021, 8, 11, 9, 0, 0, 0)
+
+	for _, s := range []string{"yesterday 3pm", "in 2 hours", "next friday"} {
+		r, _ := datetime.ParseRelative(s, ref, z)
+		if r.IsInterval {
+			_, _ = fmt.Println(s, "=", r.Interval)
+		} else {
+			_, _ = fmt.Println(s, "=", r.Time.ISOString())
+		}
+	}
+	// Output:
+	// yesterday 3pm = 2021-08-10T14:00:00Z
+	// in 2 hours = 2021-08-11T11:00:00Z
+	// next friday = [2021-08-12T23:00:00Z, 2021-08-13T23:00:00Z)
+}
@@ -0,0 +1,93 @@
+package datetime
+
+import (
+	"maps"
+	"strings"
+	"sync"
+	"time"
+)
+
+// englishVocabulary is built on first use and shared by ParseRelative
+//
+//nolint:gochecknoglobals // built once, never modified
+var englishVocabulary = sync.OnceValue(newEnglishVocabulary)
+
+// EnglishVocabulary returns a new copy of the English vocabulary for ParseRelative
+func EnglishVocabulary() *Vocabulary {
+	return &Vocabulary{Words: maps.Clone(englishVocabulary().Words)}
+}
+
+func newEnglishVocabulary() *Vocabulary {
+	words := map[string]Word{
+		"now":       {Kind: WordNow},
+		"today":     {Kind: WordDay},
+		"tomorrow":  {Kind: WordDay, Value: 1},
+		"yesterday": {Kind: WordDay, Value: -1},
+		"next":      {Kind: WordDirection, Value: 1},
+		"coming":    {Kind: WordDirection, Value: 1},
+		"last":      {Kind: WordDirection, Value: -1},
+		"previous":  {Kind: WordDirection, Value: -1},
+		"this":      {Kind: WordDirection},
+		"noon":      {Kind: WordClock, Value: 12 * minutesPerHour},
+		"midnight":  {Kind: WordClock},
+		"am":        {Kind: WordMeridiem},
+		"pm":        {Kind: WordMeridiem, Value: hoursPerHalf},
+		"in":        {Kind: WordIn},
+		"ago":       {Kind: WordAgo},
+		"from now":  {Kind: WordLater},
+		"later":     {Kind: WordLater},
+		"start":     {Kind: WordStart},
+		"beginning": {Kind: WordStart},
+		"end":       {Kind: WordEnd},
+		"at":        {Kind: WordFiller},
+		"of":        {Kind: WordFiller},
+		"the":       {Kind: WordFiller},
+		"and":       {Kind: WordFiller},
+
+		"day after tomorrow":   {Kind: WordDay, Value: 2},
+		"day before yesterday": {Kind: WordDay, Value: -2},
+	}
+
+	numbers := []string{
+		"zero", "one", "two", "three", "four", "five", "six",
+		"seven", "eight", "nine", "ten", "eleven", "twelve",
+	}
+	for n, name := range numbers {
+		words[name] = Word{Kind: WordNumber, Value: n}
+	}
+	words["a"] = Word{Kind: WordNumber, Value: 1}
+	words["an"] = Word{Kind: WordNumber, Value: 1}
+
+	for d := range Weekday(daysPerWeek) {
+		name := d.String()
+		word := Word{Kind: WordWeekday, Value: int(d)}
+		words[strings.ToLower(name)] = word
+		words[strings.ToLower(name[:nameAbbrevLen])] = word
+	}
+	words["tues"] = Word{Kind: WordWeekday, Value: int(time.Tuesday)}
+	words["thurs"] = Word{Kind: WordWeekday, Value: int(time.Thursday)}
+
+	addUnitWords(words, Word{Kind: WordDurationUnit, Duration: Seconds(1)}, "s", "sec",
+		"second")
+	addUnitWords(words, Word{Kind: WordDurationUnit, Duration: Minutes(1)}, "m", "min",
+		"minute")
+	addUnitWords(words, Word{Kind: WordDurationUnit, Duration: Hours(1)}, "h", "hr",
+		"hour")
+	addUnitWords(words, Word{Kind: WordCalendarUnit, Unit: UnitDay}, "d", "day")
+	addUnitWords(words, Word{Kind: WordCalendarUnit, Unit: UnitWeek}, "w", "wk", "week")
+	addUnitWords(words, Word{Kind: WordCalendarUnit, Unit: UnitMonth}, "mo", "month")
+	addUnitWords(words, Word{Kind: WordCalendarUnit, Unit: UnitQuarter}, "quarter")
+	addUnitWords(words, Word{Kind: WordCalendarUnit, Unit: UnitYear}, "y", "yr", "year")
+
+	return &Vocabulary{Words: words}
+}
+
+// addUnitWords adds names of a unit, words longer than one letter also in plural
+func addUnitWords(words map[string]Word, unit Word, names ...string) {
+	for _, name := range names {
+		words[name] = unit
+		if len(name) > 1 {
+			words[name+"s"] = unit
+		}
+	}
+}
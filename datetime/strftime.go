@@ -0,0 +1,947 @@
+package datetime
+
+/*
+strftime and strptime style formats.
+
+CompileStrftime converts a format like "%Y-%m-%dT%H:%M:%S" once, the result formats
+and parses times. Supported directives:
+
+	%a %A       abbreviated and full weekday name (Mon, Monday)
+	%b %h %B    abbreviated and full month name (Jan, January)
+	%C          century (20)
+	%d %e       day of month, zero and space padded (02, " 2")
+	%f          microseconds (000123), parsing accepts 1 to 9 fractional digits
+	%G %g       ISO 8601 week-based year with 4 and 2 digits
+	%H %k       hour (00-23), zero and space padded
+	%I %l       hour (01-12), zero and space padded
+	%j          day of year (001-366)
+	%m %M %S    month, minute and second
+	%p %P       AM/PM and am/pm
+	%s          seconds since the Unix epoch
+	%u %w       day of week, 1-7 from Monday and 0-6 from Sunday
+	%U %W       week of year (00-53), weeks start on Sunday and Monday
+	%V          ISO 8601 week number (01-53)
+	%y %Y       year with 2 and 4 digits
+	%z %:z      UTC offset, formatted as +0000 and +00:00
+	%Z          time zone name, formatted as UTC
+	%n %t %%    newline, tab and percent sign
+	%c %D %F %r %R %T %x %X
+	            composites as in the C locale, for example %F is %Y-%m-%d
+
+A flag between % and the directive changes padding of numbers: "-" for none, "_" for
+spaces and "0" for zeros, so %-d gives "2" and %_m gives " 1".
+
+Parsing follows strptime. Names are matched ignoring case, %n and %t match any amount
+of white space, two digit years are 1969 to 2068. Fields missing from the format
+default to year 0, January 1, midnight like in time.Parse, the parsed offset is
+applied to return UTC. The date is taken from the first of:
+
+ 1. %s, which also sets the time of day
+ 2. %G, %g or %V with an optional weekday (default Monday)
+ 3. %j
+ 4. %U or %W with an optional weekday (default first day of the week)
+ 5. month and day
+
+Go layouts have no equivalent for directives like %C, %G, %U or %s, so the format is
+always applied by StrftimeFormat itself. PrintFormat returns the Go layout when one
+exists, for use with APIs taking a PrintFormat.
+*/
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrUnknownDirective is returned when a strftime format has an unsupported directive
+var ErrUnknownDirective = errors.New("datetime: unknown strftime directive")
+
+// StrftimeFormat is a compiled strftime format
+type StrftimeFormat struct {
+	format string
+	items  []strftimeItem
+}
+
+// strftimeItem is either literal text or a single directive
+type strftimeItem struct {
+	lit   string
+	verb  byte
+	pad   byte // '0', ' ' or 0 for no padding
+	colon bool
+}
+
+// strptimeFields collects parsed values, set has a bit for each parsed directive
+type strptimeFields struct {
+	set     uint64
+	year    int
+	yy      int
+	century int
+	isoYear int
+	isoYY   int
+	month   int
+	day     int
+	yday    int
+	week    int
+	weekday Weekday
+	hour    int
+	minute  int
+	second  int
+	nsec    int
+	pm      bool
+	epoch   int64
+	offset  int64
+}
+
+const (
+	strftimeVerbs   = "aAbBCdefGghHIjklmMnpPsStuUVwWyYzZ"
+	nameAbbrevLen   = 3
+	yearsPerCentury = 100
+	// Two digit years below the pivot are in the 21st century
+	twoDigitYearPivot = 69
+	maxEpochDigits    = 19
+)
+
+/*
+CompileStrftime parses a strftime format, see the list of supported directives above.
+
+An error wrapping ErrUnknownDirective is returned for unsupported directives.
+*/
+func CompileStrftime(format string) (StrftimeFormat, error) {
+	items, err := appendStrftimeItems(nil, format)
+	if err != nil {
+		return StrftimeFormat{}, err
+	}
+
+	return StrftimeFormat{format: format, items: items}, nil
+}
+
+// Strftime formats the time with a strftime format
+func (t Time) Strftime(format string) (string, error) {
+	f, err := CompileStrftime(format)
+	if err != nil {
+		return "", err
+	}
+	return f.Format(t), nil
+}
+
+// Strptime parses s with a strftime format
+func Strptime(s, format string) (Time, error) {
+	f, err := CompileStrftime(format)
+	if err != nil {
+		return Time{}, err
+	}
+	return f.Parse(s)
+}
+
+func appendStrftimeItems(items []strftimeItem, format string) ([]strftimeItem, error) {
+	for format != "" {
+		i := strings.IndexByte(format, '%')
+		if i < 0 {
+			return appendLiteral(items, format), nil
+		}
+		items = appendLiteral(items, format[:i])
+
+		item, n, err := parseDirective(format[i:])
+		if err != nil {
+			return nil, err
+		}
+		format = format[i+n:]
+
+		switch expansion := strftimeComposite(item.verb); {
+		case item.verb == '%':
+			items = appendLiteral(items, "%")
+		case expansion != "":
+			// Expansions only use supported directives
+			items, _ = appendStrftimeItems(items, expansion)
+		default:
+			items = append(items, item)
+		}
+	}
+
+	return items, nil
+}
+
+// appendLiteral appends literal text, merging it with a preceding literal
+func appendLiteral(items []strftimeItem, lit string) []strftimeItem {
+	if lit == "" {
+		return items
+	}
+
+	if n := len(items); n > 0 && items[n-1].verb == 0 {
+		items[n-1].lit += lit
+		return items
+	}
+
+	return append(items, strftimeItem{lit: lit})
+}
+
+// parseDirective parses the directive at the start of s, returns it and its length
+func parseDirective(s string) (strftimeItem, int, error) {
+	var item strftimeItem
+	n, flag := 1, byte(0)
+
+	if n < len(s) && strings.IndexByte("-_0", s[n]) >= 0 {
+		flag = s[n]
+		n++
+	}
+
+	if n < len(s) && s[n] == ':' {
+		item.colon = true
+		n++
+	}
+
+	if n == len(s) {
+		return item, n, fmt.Errorf("%w %q", ErrUnknownDirective, s)
+	}
+
+	item.verb = s[n]
+	n++
+
+	known := item.verb == '%' || strftimeComposite(item.verb) != "" ||
+		strings.IndexByte(strftimeVerbs, item.verb) >= 0
+	if !known || item.colon && item.verb != 'z' {
+		return item, n, fmt.Errorf("%w %q", ErrUnknownDirective, s[:n])
+	}
+
+	switch flag {
+	case '-':
+		item.pad = 0
+	case '_':
+		item.pad = ' '
+	case '0':
+		item.pad = '0'
+	default:
+		item.pad = strftimeDefaultPad(item.verb)
+	}
+
+	return item, n, nil
+}
+
+// strftimeComposite returns expansion of a composite directive, or "" for others
+func strftimeComposite(verb byte) string {
+	switch verb {
+	case 'c':
+		return "%a %b %e %H:%M:%S %Y"
+	case 'D', 'x':
+		return "%m/%d/%y"
+	case 'F':
+		return "%Y-%m-%d"
+	case 'r':
+		return "%I:%M:%S %p"
+	case 'R':
+		return "%H:%M"
+	case 'T', 'X':
+		return "%H:%M:%S"
+	default:
+		return ""
+	}
+}
+
+func strftimeDefaultPad(verb byte) byte {
+	switch verb {
+	case 'e', 'k', 'l':
+		return ' '
+	case 's':
+		return 0
+	default:
+		return '0'
+	}
+}
+
+// strftimeWidth returns number of digits of a numeric directive
+//
+//nolint:mnd // field widths
+func strftimeWidth(verb byte) int {
+	switch verb {
+	case 'Y', 'G':
+		return 4
+	case 'j':
+		return 3
+	case 'f':
+		return 6
+	case 'u', 'w', 's':
+		return 1
+	default:
+		return 2
+	}
+}
+
+// String returns the strftime format
+func (f StrftimeFormat) String() string {
+	return f.format
+}
+
+// Format returns the time formatted according to the format
+func (f StrftimeFormat) Format(t Time) string {
+	return string(f.AppendFormat(nil, t))
+}
+
+// AppendFormat is like Format but appends to dst and returns the extended buffer
+func (f StrftimeFormat) AppendFormat(dst []byte, t Time) []byte {
+	for _, item := range f.items {
+		if item.verb == 0 {
+			dst = append(dst, item.lit...)
+			continue
+		}
+
+		if v, ok := strftimeNumber(t, item.verb); ok {
+			dst = appendPadded(dst, v, strftimeWidth(item.verb), item.pad)
+			continue
+		}
+
+		dst = appendStrftimeText(dst, t, item)
+	}
+
+	return dst
+}
+
+// strftimeNumber returns value of a numeric directive
+func strftimeNumber(t Time, verb byte) (int64, bool) {
+	switch verb {
+	case 'H', 'k':
+		return int64(t.Hour()), true
+	case 'I', 'l':
+		return int64(hour12(t.Hour())), true
+	case 'M':
+		return int64(t.Minute()), true
+	case 'S':
+		return int64(t.Second()), true
+	case 'f':
+		return int64(t.Nanosecond() / int(Microseconds(1))), true
+	case 's':
+		return t.Unix(), true
+	default:
+		v, ok := strftimeDateNumber(t, verb)
+		return int64(v), ok
+	}
+}
+
+func strftimeDateNumber(t Time, verb byte) (int, bool) {
+	switch verb {
+	case 'Y':
+		return t.Year(), true
+	case 'C':
+		return century(t.Year()), true
+	case 'y':
+		return t.Year() - century(t.Year())*yearsPerCentury, true
+	case 'G', 'g', 'V':
+		return strftimeISONumber(t, verb), true
+	case 'm':
+		return int(t.Month()), true
+	case 'd', 'e':
+		return t.Day(), true
+	case 'j':
+		return t.YearDay(), true
+	case 'U':
+		return (t.YearDay() - 1 + daysPerWeek - int(t.Weekday())) / daysPerWeek, true
+	case 'W':
+		monday := t.ISOWeekday() - 1
+		return (t.YearDay() - 1 + daysPerWeek - monday) / daysPerWeek, true
+	case 'u':
+		return t.ISOWeekday(), true
+	case 'w':
+		return int(t.Weekday()), true
+	default:
+		return 0, false
+	}
+}
+
+func strftimeISONumber(t Time, verb byte) int {
+	year, week := t.ISOWeek()
+
+	switch verb {
+	case 'G':
+		return year
+	case 'g':
+		return year - century(year)*yearsPerCentury
+	default:
+		return week
+	}
+}
+
+func century(year int) int {
+	return int(floorDiv(int64(year), yearsPerCentury))
+}
+
+//nolint:mnd // hours on a 12-hour clock
+func hour12(hour int) int {
+	if hour%12 == 0 {
+		return 12
+	}
+	return hour % 12
+}
+
+// appendPadded appends v padded with pad to width digits
+func appendPadded(dst []byte, v int64, width int, pad byte) []byte {
+	if v < 0 {
+		dst = append(dst, '-')
+		v = -v
+	}
+
+	if pad != 0 {
+		digits := 1
+		for x := v; x >= 10; x /= 10 { //nolint:mnd // decimal digits
+			digits++
+		}
+
+		for ; digits < width; digits++ {
+			dst = append(dst, pad)
+		}
+	}
+
+	return strconv.AppendInt(dst, v, 10) //nolint:mnd // decimal
+}
+
+func appendStrftimeText(dst []byte, t Time, item strftimeItem) []byte {
+	pm := t.Hour() >= 12 //nolint:mnd // noon
+
+	switch item.verb {
+	case 'a':
+		return append(dst, t.Weekday().String()[:nameAbbrevLen]...)
+	case 'A':
+		return append(dst, t.Weekday().String()...)
+	case 'b', 'h':
+		return append(dst, t.Month().String()[:nameAbbrevLen]...)
+	case 'B':
+		return append(dst, t.Month().String()...)
+	case 'p':
+		if pm {
+			return append(dst, "PM"...)
+		}
+		return append(dst, "AM"...)
+	case 'P':
+		if pm {
+			return append(dst, "pm"...)
+		}
+		return append(dst, "am"...)
+	case 'z':
+		if item.colon {
+			return append(dst, "+00:00"...)
+		}
+		return append(dst, "+0000"...)
+	case 'Z':
+		return append(dst, "UTC"...)
+	case 'n':
+		return append(dst, '\n')
+	case 't':
+		return append(dst, '\t')
+	default:
+		return dst
+	}
+}
+
+/*
+PrintFormat returns the Go layout equivalent to the format.
+
+The second result is false if the format has directives Go layouts can't express, or
+literal text which Go would interpret as part of a layout.
+*/
+func (f StrftimeFormat) PrintFormat() (PrintFormat, bool) {
+	var sb strings.Builder
+
+	for i, item := range f.items {
+		if item.verb == 0 {
+			if !isLayoutLiteral(item.lit, f.items[:i]) {
+				return "", false
+			}
+			sb.WriteString(item.lit)
+			continue
+		}
+
+		layout := goLayoutText(item)
+		if layout == "" {
+			layout = goLayoutNumber(item)
+		}
+
+		// Unpadded numbers can merge with the next one, like "1" and "5" into "15"
+		next := i + 1
+		if layout == "" ||
+			item.pad == 0 && isDigit(layout[0]) && next < len(f.items) &&
+				f.items[next].verb != 0 {
+			return "", false
+		}
+		sb.WriteString(layout)
+	}
+
+	return PrintFormat(sb.String()), true
+}
+
+// isLayoutLiteral reports whether Go layouts treat lit as literal text
+func isLayoutLiteral(lit string, before []strftimeItem) bool {
+	if strings.ContainsAny(lit, "0123456789JMPpZ_") {
+		return false
+	}
+
+	// "Mon" followed by "day" is the full weekday name, same for "Jan" and "uary"
+	if n := len(before); n > 0 && strings.IndexByte("abh", before[n-1].verb) >= 0 {
+		return lit[0] < 'a' || lit[0] > 'z'
+	}
+
+	return true
+}
+
+func goLayoutText(item strftimeItem) string {
+	switch item.verb {
+	case 'a':
+		return "Mon"
+	case 'A':
+		return "Monday"
+	case 'b', 'h':
+		return "Jan"
+	case 'B':
+		return "January"
+	case 'p':
+		return "PM"
+	case 'P':
+		return "pm"
+	case 'z':
+		if item.colon {
+			return "-07:00"
+		}
+		return "-0700"
+	case 'Z':
+		return "MST"
+	case 'n':
+		return "\n"
+	case 't':
+		return "\t"
+	default:
+		return ""
+	}
+}
+
+func goLayoutNumber(item strftimeItem) string {
+	var zero, space, none string
+
+	switch item.verb {
+	case 'd', 'e':
+		zero, space, none = "02", "_2", "2"
+	case 'H':
+		zero = "15"
+	case 'I':
+		zero, none = "03", "3"
+	case 'j':
+		zero, space = "002", "__2"
+	case 'm':
+		zero, none = "01", "1"
+	case 'M':
+		zero, none = "04", "4"
+	case 'S':
+		zero, none = "05", "5"
+	case 'y':
+		zero = "06"
+	case 'Y':
+		zero = "2006"
+	default:
+	}
+
+	switch item.pad {
+	case '0':
+		return zero
+	case ' ':
+		return space
+	default:
+		return none
+	}
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+/*
+Parse parses s according to the format and returns the time in UTC.
+
+Errors wrap ErrInvalidSyntax when s doesn't match the format, or ErrOutOfRange when a
+field is outside its valid range.
+*/
+func (f StrftimeFormat) Parse(s string) (Time, error) {
+	var fields strptimeFields
+
+	rest := s
+	for _, item := range f.items {
+		var err error
+		if rest, err = fields.parseItem(rest, item); err != nil {
+			return Time{}, f.parseError(s, err)
+		}
+	}
+
+	if rest != "" {
+		err := fmt.Errorf("extra text %q: %w", rest, ErrInvalidSyntax)
+		return Time{}, f.parseError(s, err)
+	}
+
+	t, err := fields.time()
+	if err != nil {
+		return Time{}, f.parseError(s, err)
+	}
+
+	return t, nil
+}
+
+func (f StrftimeFormat) parseError(s string, err error) error {
+	return fmt.Errorf("datetime: parsing %q as %q: %w", s, f.format, err)
+}
+
+func verbBit(verb byte) uint64 {
+	return 1 << (verb - 'A')
+}
+
+// has reports whether any of verbs was parsed
+func (p *strptimeFields) has(verbs string) bool {
+	for i := range len(verbs) {
+		if p.set&verbBit(verbs[i]) != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// parseItem parses item at the start of s and returns the rest of s
+func (p *strptimeFields) parseItem(s string, item strftimeItem) (string, error) {
+	switch item.verb {
+	case 0:
+		if !strings.HasPrefix(s, item.lit) {
+			return s, ErrInvalidSyntax
+		}
+		return s[len(item.lit):], nil
+	case 'n', 't':
+		return strings.TrimLeft(s, " \t\n\v\f\r"), nil
+	case 'a', 'A', 'b', 'B', 'h', 'p', 'P':
+		return p.parseName(s, item.verb)
+	case 'z':
+		return p.parseOffset(s)
+	case 'Z':
+		for _, zone := range []string{"UTC", "GMT", "Z"} {
+			if strings.HasPrefix(s, zone) {
+				return s[len(zone):], nil
+			}
+		}
+		return s, ErrInvalidSyntax
+	default:
+		return p.parseNumber(s, item)
+	}
+}
+
+// parseName parses a weekday name, a month name or AM/PM
+func (p *strptimeFields) parseName(s string, verb byte) (string, error) {
+	switch verb {
+	case 'a', 'A':
+		for d := range Weekday(daysPerWeek) {
+			if n := matchName(s, d.String()); n > 0 {
+				p.weekday = d
+				p.set |= verbBit('w')
+				return s[n:], nil
+			}
+		}
+	case 'b', 'B', 'h':
+		for m := time.January; m <= time.December; m++ {
+			if n := matchName(s, m.String()); n > 0 {
+				p.month = int(m)
+				p.set |= verbBit('m')
+				return s[n:], nil
+			}
+		}
+	default:
+		if len(s) >= len("AM") && (strings.EqualFold(s[:2], "AM") ||
+			strings.EqualFold(s[:2], "PM")) {
+			p.pm = s[0] == 'P' || s[0] == 'p'
+			p.set |= verbBit('p')
+			return s[2:], nil
+		}
+	}
+
+	return s, ErrInvalidSyntax
+}
+
+// matchName returns length of name or its abbreviation at the start of s, or zero
+func matchName(s, name string) int {
+	if len(s) >= len(name) && strings.EqualFold(s[:len(name)], name) {
+		return len(name)
+	}
+
+	abbrev := name[:nameAbbrevLen]
+	if len(s) >= nameAbbrevLen && strings.EqualFold(s[:nameAbbrevLen], abbrev) {
+		return nameAbbrevLen
+	}
+
+	return 0
+}
+
+// parseOffset parses "Z" or "±hh", "±hhmm" and "±hh:mm"
+//
+//nolint:mnd // field offsets of numeric offset
+func (p *strptimeFields) parseOffset(s string) (string, error) {
+	p.set |= verbBit('z')
+
+	if s != "" && (s[0] == 'Z' || s[0] == 'z') {
+		return s[1:], nil
+	}
+
+	if len(s) < len("+hh") || s[0] != '+' && s[0] != '-' {
+		return s, ErrInvalidSyntax
+	}
+
+	hh, ok := parseDigits(s[1:3])
+	if !ok {
+		return s, ErrInvalidSyntax
+	}
+
+	rest, mm := s[3:], 0
+	if minutes := strings.TrimPrefix(rest, ":"); len(minutes) >= 2 {
+		if v, ok := parseDigits(minutes[:2]); ok {
+			rest, mm = minutes[2:], v
+		}
+	}
+
+	if hh > 23 || mm > 59 {
+		return s, ErrOutOfRange
+	}
+
+	p.offset = int64(hh*secondsPerHour + mm*secondsPerMinute)
+	if s[0] == '-' {
+		p.offset = -p.offset
+	}
+
+	return rest, nil
+}
+
+// parseNumber parses up to width of the directive digits
+func (p *strptimeFields) parseNumber(s string, item strftimeItem) (string, error) {
+	if item.pad == ' ' {
+		s = strings.TrimLeft(s, " ")
+	}
+
+	start, width := 0, strftimeWidth(item.verb)
+	switch item.verb {
+	case 's':
+		if strings.HasPrefix(s, "-") {
+			start = 1
+		}
+		width = maxEpochDigits
+	case 'f':
+		width = maxFracDigits
+	default:
+	}
+
+	n := start
+	for n < len(s) && n-start < width && isDigit(s[n]) {
+		n++
+	}
+
+	if n == start {
+		return s, ErrInvalidSyntax
+	}
+
+	v, err := strconv.ParseInt(s[:n], 10, 64) //nolint:mnd // decimal
+	if err != nil {
+		return s, ErrOutOfRange
+	}
+
+	if item.verb == 'f' {
+		for range maxFracDigits - n {
+			v *= 10 //nolint:mnd // decimal digits
+		}
+	}
+
+	if err := p.setNumber(item.verb, v); err != nil {
+		return s, err
+	}
+
+	return s[n:], nil
+}
+
+func (p *strptimeFields) setNumber(verb byte, v int64) error {
+	p.set |= verbBit(verb)
+
+	switch n := int(v); verb {
+	case 'H', 'k', 'I', 'l':
+		p.hour = n
+	case 'M':
+		p.minute = n
+	case 'S':
+		p.second = n
+	case 'f':
+		p.nsec = n
+	case 's':
+		p.epoch = v
+	case 'u', 'w':
+		if verb == 'u' && (n < 1 || n > daysPerWeek) ||
+			verb == 'w' && n >= daysPerWeek {
+			return ErrOutOfRange
+		}
+		p.weekday = Weekday(n % daysPerWeek)
+		p.set |= verbBit('w')
+	default:
+		p.setDateNumber(verb, n)
+	}
+
+	return nil
+}
+
+func (p *strptimeFields) setDateNumber(verb byte, n int) {
+	switch verb {
+	case 'Y':
+		p.year = n
+	case 'y':
+		p.yy = n
+	case 'C':
+		p.century = n
+	case 'G':
+		p.isoYear = n
+	case 'g':
+		p.isoYY = n
+	case 'm':
+		p.month = n
+	case 'd', 'e':
+		p.day = n
+		p.set |= verbBit('d')
+	case 'j':
+		p.yday = n
+	default:
+		// Week of year directives
+		p.week = n
+	}
+}
+
+//nolint:mnd // two digit years
+func pivotYear(yy int) int {
+	if yy < twoDigitYearPivot {
+		return 2000 + yy
+	}
+	return 1900 + yy
+}
+
+func (p *strptimeFields) gregorianYear() int {
+	switch {
+	case p.has("Y"):
+		return p.year
+	case p.has("y") && p.has("C"):
+		return p.century*yearsPerCentury + p.yy
+	case p.has("y"):
+		return pivotYear(p.yy)
+	default:
+		return p.century * yearsPerCentury
+	}
+}
+
+func (p *strptimeFields) isoWeekYear() int {
+	switch {
+	case p.has("G"):
+		return p.isoYear
+	case p.has("g"):
+		return pivotYear(p.isoYY)
+	default:
+		return p.gregorianYear()
+	}
+}
+
+// date returns midnight of the parsed date
+//
+//nolint:mnd // days in year
+func (p *strptimeFields) date() (Time, error) {
+	year := p.gregorianYear()
+
+	switch {
+	case p.has("GgV"):
+		weekday := time.Monday
+		if p.has("w") {
+			weekday = p.weekday
+		}
+
+		isoYear := p.isoWeekYear()
+		if p.has("V") && (p.week < 1 || p.week > ISOWeeksInYear(isoYear)) {
+			return Time{}, ErrOutOfRange
+		}
+
+		return ISOWeekDate(isoYear, max(p.week, 1), weekday), nil
+	case p.has("j"):
+		daysInYear := 365
+		if IsLeapYear(year) {
+			daysInYear++
+		}
+
+		if p.yday < 1 || p.yday > daysInYear {
+			return Time{}, ErrOutOfRange
+		}
+		return Date(year, 1, p.yday, 0, 0, 0, 0), nil
+	case p.has("UW"):
+		if p.week > 53 {
+			return Time{}, ErrOutOfRange
+		}
+		return p.weekOfYearDate(year), nil
+	default:
+		month, day := 1, 1
+		if p.has("m") {
+			month = p.month
+		}
+		if p.has("d") {
+			day = p.day
+		}
+
+		if month < 1 || month > monthsPerYear || day < 1 ||
+			day > DaysInMonth(year, Month(month)) {
+			return Time{}, ErrOutOfRange
+		}
+
+		return Date(year, month, day, 0, 0, 0, 0), nil
+	}
+}
+
+// weekOfYearDate returns the date for %U or %W week number and weekday
+func (p *strptimeFields) weekOfYearDate(year int) Time {
+	jan1 := Date(year, 1, 1, 0, 0, 0, 0)
+
+	weekStart := time.Sunday
+	if p.has("W") {
+		weekStart = time.Monday
+	}
+
+	weekday := weekStart
+	if p.has("w") {
+		weekday = p.weekday
+	}
+
+	// Week 1 starts on the first weekStart of the year, days before it are in week 0
+	firstWeek := (int(weekStart) - int(jan1.Weekday()) + daysPerWeek) % daysPerWeek
+	dayInWeek := (int(weekday) - int(weekStart) + daysPerWeek) % daysPerWeek
+
+	return jan1.AddDate(0, 0, firstWeek+(p.week-1)*daysPerWeek+dayInWeek)
+}
+
+// time returns the parsed time in UTC
+//
+//nolint:mnd // ranges of time fields
+func (p *strptimeFields) time() (Time, error) {
+	if p.has("s") {
+		return Unix(p.epoch, int64(p.nsec)), nil
+	}
+
+	date, err := p.date()
+	if err != nil {
+		return Time{}, err
+	}
+
+	hour := p.hour
+	if p.has("Il") {
+		if hour < 1 || hour > 12 {
+			return Time{}, ErrOutOfRange
+		}
+
+		hour %= 12
+		if p.pm {
+			hour += 12
+		}
+	}
+
+	if hour > 23 || p.minute > 59 || p.second > 59 {
+		return Time{}, ErrOutOfRange
+	}
+
+	offset := Hours(int64(hour)) + Minutes(int64(p.minute)) +
+		Seconds(int64(p.second)-p.offset) + Nanoseconds(int64(p.nsec))
+
+	return date.Add(offset), nil
+}
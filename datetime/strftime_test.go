@@ -0,0 +1,276 @@
+package datetime_test
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"github.com/ram-nad/go-utils/datetime"
+)
+
+func TestStrftimeFormat(t *testing.T) {
+	dt := datetime.Date(2021, 1, 3, 14, 5, 9, 123456789)
+
+	tests := []struct {
+		format   string
+		expected string
+	}{
+		{"%Y-%m-%dT%H:%M:%S", "2021-01-03T14:05:09"},
+		{"%a %A %b %h %B", "Sun Sunday Jan Jan January"},
+		{"%C %y %G %g", "20 21 2020 20"},
+		{"%d|%e|%-d|%_m|%0e", "03| 3|3| 1|03"},
+		{"%H %k %I %l %-I", "14 14 02  2 2"},
+		{"%j %U %W %V %u %w", "003 01 00 53 7 0"},
+		{"%p %P", "PM pm"},
+		{"%f", "123456"},
+		{"%s", "1609682709"},
+		{"%z %:z %Z", "+0000 +00:00 UTC"},
+		{"%%%n%t", "%\n\t"},
+		{"%c", "Sun Jan  3 14:05:09 2021"},
+		{"%D %F", "01/03/21 2021-01-03"},
+		{"%r %R %T", "02:05:09 PM 14:05 14:05:09"},
+		{"%x %X", "01/03/21 14:05:09"},
+		{"plain text", "plain text"},
+		{"", ""},
+	}
+
+	for _, tc := range tests {
+		got, err := dt.Strftime(tc.format)
+		if err != nil || got != tc.expected {
+			t.Errorf("Strftime(%q) = %q, %v, want %q", tc.format, got, err, tc.expected)
+		}
+	}
+}
+
+func TestStrftimeWeekNumbers(t *testing.T) {
+	start := datetime.Date(2015, 1, 1, 0, 0, 0, 0)
+	u, _ := datetime.CompileStrftime("%U")
+	w, _ := datetime.CompileStrftime("%W")
+	v, _ := datetime.CompileStrftime("%G-%V-%u")
+
+	for day := range 366 * 10 {
+		dt := start.AddDate(0, 0, day)
+		yday, weekday := dt.YearDay()-1, int(dt.Weekday())
+
+		// Week numbers as defined by C strftime
+		expectedU := fmt.Sprintf("%02d", (yday+7-weekday)/7)
+		expectedW := fmt.Sprintf("%02d", (yday+7-(weekday+6)%7)/7)
+		isoYear, isoWeek := time.Time(dt).ISOWeek()
+		expectedV := fmt.Sprintf("%d-%02d-%d", isoYear, isoWeek, dt.ISOWeekday())
+
+		if got := u.Format(dt); got != expectedU {
+			t.Fatalf("%v: %%U = %s, want %s", dt, got, expectedU)
+		}
+
+		if got := w.Format(dt); got != expectedW {
+			t.Fatalf("%v: %%W = %s, want %s", dt, got, expectedW)
+		}
+
+		if got := v.Format(dt); got != expectedV {
+			t.Fatalf("%v: %%G-%%V-%%u = %s, want %s", dt, got, expectedV)
+		}
+	}
+}
+
+func TestStrftimePrintFormat(t *testing.T) {
+	tests := []struct {
+		format   string
+		expected datetime.PrintFormat
+		ok       bool
+	}{
+		{"%Y-%m-%dT%H:%M:%S%z", "2006-01-02T15:04:05-0700", true},
+		{"%a, %d %b %Y %H:%M:%S %Z", datetime.RFC1123, true},
+		{"%c", "Mon Jan _2 15:04:05 2006", true},
+		{"%-m/%-d/%y %I:%M %p", "1/2/06 03:04 PM", true},
+		{"%j %B %A %P %:z", "002 January Monday pm -07:00", true},
+		{"%s", "", false},
+		{"%G-W%V", "", false},
+		{"%H%M %k", "", false},
+		{"Day %d", "Day 02", true},
+		{"Month %m", "", false},
+		{"%aday", "", false},
+		{"%-m%-d", "", false},
+	}
+
+	dt := datetime.Date(2021, 8, 9, 16, 7, 3, 0)
+	for _, tc := range tests {
+		f, err := datetime.CompileStrftime(tc.format)
+		if err != nil {
+			t.Fatalf("CompileStrftime(%q) = %v", tc.format, err)
+		}
+
+		got, ok := f.PrintFormat()
+		if got != tc.expected || ok != tc.ok {
+			t.Errorf(
+				"CompileStrftime(%q).PrintFormat() = %q, %t, want %q, %t",
+				tc.format,
+				got,
+				ok,
+				tc.expected,
+				tc.ok,
+			)
+		}
+
+		if ok && dt.Format(got) != f.Format(dt) {
+			t.Errorf(
+				"%q: Format() = %q, want %q",
+				tc.format,
+				f.Format(dt),
+				dt.Format(got),
+			)
+		}
+	}
+}
+
+func TestStrptime(t *testing.T) {
+	tests := []struct {
+		s        string
+		format   string
+		expected datetime.Time
+	}{
+		{
+			"2021-08-09T16:07:03",
+			"%Y-%m-%dT%H:%M:%S",
+			datetime.Date(2021, 8, 9, 16, 7, 3, 0),
+		},
+		{"20210809", "%Y%m%d", datetime.Date(2021, 8, 9, 0, 0, 0, 0)},
+		{"9/8/21", "%d/%m/%y", datetime.Date(2021, 8, 9, 0, 0, 0, 0)},
+		{"9/8/69", "%d/%m/%y", datetime.Date(1969, 8, 9, 0, 0, 0, 0)},
+		{"19 21-08-09", "%C %y-%m-%d", datetime.Date(1921, 8, 9, 0, 0, 0, 0)},
+		{
+			"monday, 9 AUGUST 2021 4:07 pm",
+			"%A, %e %B %Y %l:%M %p",
+			datetime.Date(2021, 8, 9, 16, 7, 0, 0),
+		},
+		{"Aug  9 12:00:00 AM", "%b %e %r", datetime.Date(0, 8, 9, 0, 0, 0, 0)},
+		{"2021 221", "%Y %j", datetime.Date(2021, 8, 9, 0, 0, 0, 0)},
+		{"2020-W53-5", "%G-W%V-%u", datetime.Date(2021, 1, 1, 0, 0, 0, 0)},
+		{"21 32 1", "%y %U %w", datetime.Date(2021, 8, 9, 0, 0, 0, 0)},
+		{"2021 32 Mon", "%Y %W %a", datetime.Date(2021, 8, 9, 0, 0, 0, 0)},
+		{"1628525223.5", "%s.%f", datetime.Date(2021, 8, 9, 16, 7, 3, 500_000_000)},
+		{"-1", "%s", datetime.Date(1969, 12, 31, 23, 59, 59, 0)},
+		{"16:07:03.123", "%T.%f", datetime.Date(0, 1, 1, 16, 7, 3, 123_000_000)},
+		{
+			"2021-08-09 21:37:03 +05:30",
+			"%F %T %z",
+			datetime.Date(2021, 8, 9, 16, 7, 3, 0),
+		},
+		{"2021-08-09 11:07 -0500", "%F %R %z", datetime.Date(2021, 8, 9, 16, 7, 0, 0)},
+		{"2021-08-09 16:07 Z", "%F %R %z", datetime.Date(2021, 8, 9, 16, 7, 0, 0)},
+		{"2021-08-09 16:07 UTC", "%F %R %Z", datetime.Date(2021, 8, 9, 16, 7, 0, 0)},
+		{"2021-08-09\n \t16", "%F%n%H", datetime.Date(2021, 8, 9, 16, 0, 0, 0)},
+		{"100%", "%j%%", datetime.Date(0, 4, 9, 0, 0, 0, 0)},
+	}
+
+	for _, tc := range tests {
+		got, err := datetime.Strptime(tc.s, tc.format)
+		if err != nil || !got.Equal(tc.expected) {
+			t.Errorf(
+				"Strptime(%q, %q) = %v, %v, want %v",
+				tc.s,
+				tc.format,
+				got,
+				err,
+				tc.expected,
+			)
+		}
+	}
+}
+
+func TestStrptimeErrors(t *testing.T) {
+	tests := []struct {
+		s      string
+		format string
+		err    error
+	}{
+		{"2021-08-09", "%Y-%m-%d %H", datetime.ErrInvalidSyntax},
+		{"2021-08-09 junk", "%Y-%m-%d", datetime.ErrInvalidSyntax},
+		{"2021/08/09", "%Y-%m-%d", datetime.ErrInvalidSyntax},
+		{"Foo 9", "%b %d", datetime.ErrInvalidSyntax},
+		{"2021-02-29", "%F", datetime.ErrOutOfRange},
+		{"2021-13-01", "%F", datetime.ErrOutOfRange},
+		{"2021-00-01", "%F", datetime.ErrOutOfRange},
+		{"24:00", "%H:%M", datetime.ErrOutOfRange},
+		{"13 PM", "%I %p", datetime.ErrOutOfRange},
+		{"2021 366", "%Y %j", datetime.ErrOutOfRange},
+		{"2021-W53", "%G-W%V", datetime.ErrOutOfRange},
+		{"8", "%u", datetime.ErrOutOfRange},
+		{"+2400", "%z", datetime.ErrOutOfRange},
+		{"EST", "%Z", datetime.ErrInvalidSyntax},
+		{"2021", "%Q", datetime.ErrUnknownDirective},
+		{"2021", "%Y%", datetime.ErrUnknownDirective},
+		{"2021", "%:Y", datetime.ErrUnknownDirective},
+	}
+
+	for _, tc := range tests {
+		if _, err := datetime.Strptime(tc.s, tc.format); !errors.Is(err, tc.err) {
+			t.Errorf("Strptime(%q, %q) = %v, want %v", tc.s, tc.format, err, tc.err)
+		}
+	}
+}
+
+func TestStrftimeRoundTrip(t *testing.T) {
+	formats := []string{
+		"%Y-%m-%dT%H:%M:%S.%f%z",
+		"%a %d %b %Y %I:%M:%S.%f %p",
+		"%G-W%V-%u %T.%f",
+		"%Y-%j %T.%f",
+		"%Y %U %w %T.%f",
+		"%Y %W %a %T.%f",
+		"%s.%f",
+	}
+
+	r := rand.New(rand.NewPCG(1, 2))
+	for _, format := range formats {
+		f, err := datetime.CompileStrftime(format)
+		if err != nil {
+			t.Fatalf("CompileStrftime(%q) = %v", format, err)
+		}
+
+		for range 1000 {
+			dt := datetime.Unix(r.Int64N(1<<33), int64(r.IntN(1e6))*1000)
+			s := f.Format(dt)
+
+			got, err := f.Parse(s)
+			if err != nil || !got.Equal(dt) {
+				t.Fatalf("Parse(Format(%v)) = %v, %v, formatted as %q", dt, got, err, s)
+			}
+		}
+	}
+}
+
+func BenchmarkStrftimeFormat(b *testing.B) {
+	f, _ := datetime.CompileStrftime("%Y-%m-%dT%H:%M:%S.%f%z")
+	dt := datetime.Date(2021, 8, 9, 16, 7, 3, 123456789)
+	buf := make([]byte, 0, 64)
+
+	for b.Loop() {
+		buf = f.AppendFormat(buf[:0], dt)
+	}
+}
+
+func BenchmarkStrptime(b *testing.B) {
+	f, _ := datetime.CompileStrftime("%Y-%m-%dT%H:%M:%S.%f%z")
+
+	for b.Loop() {
+		_, _ = f.Parse("2021-08-09T16:07:03.123456+0000")
+	}
+}
+
+func ExampleStrftimeFormat() {
+	f, err := datetime.CompileStrftime("%a %-d %b %Y, week %V, day %j")
+	if err != nil {
+		panic(err)
+	}
+
+	dt := datetime.Date(2021, 8, 9, 0, 0, 0, 0)
+	_, _ = fmt.Println(f.Format(dt))
+
+	parsed, _ := f.Parse("Mon 9 Aug 2021, week 32, day 221")
+	_, _ = fmt.Println(parsed.Equal(dt))
+	// Output:
+	// Mon 9 Aug 2021, week 32, day 221
+	// true
+}
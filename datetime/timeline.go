@@ -0,0 +1,143 @@
+package datetime
+
+/*
+ClockTime readings are offsets on the monotonic clock, which has no relation to the
+calendar. An Anchor pairs a wall clock reading with a monotonic clock reading taken
+at the same instant, which allows estimating when a ClockTime happened.
+
+Both readings of an Anchor come from a single call to time.Now, so they are exact pairs.
+
+The wall clock can jump (NTP step, manual change, VM resume), while the monotonic clock
+can't. Timeline keeps the current Anchor and re-anchors when it detects that the wall
+clock moved more than a threshold away from where the monotonic clock says it should be.
+
+Example:
+	timeline := datetime.NewTimeline(datetime.Seconds(1))
+	start := datetime.NowClock()
+	.... do something ....
+	fmt.Printf("started at %v", timeline.ToTime(start))
+*/
+
+import (
+	"sync"
+	"time"
+)
+
+// Anchor is a pair of wall clock and monotonic clock readings taken at the same instant
+type Anchor struct {
+	Wall  Time
+	Clock ClockTime
+}
+
+// Jump describes a detected change of the wall clock relative to the monotonic clock
+type Jump struct {
+	// Previous is the anchor in use before the jump
+	Previous Anchor
+	// Current is the anchor in use after the jump
+	Current Anchor
+	// Magnitude is how far the wall clock moved, positive if it moved forward
+	Magnitude Duration
+}
+
+// Timeline converts between ClockTime and Time, re-anchoring on wall clock jumps
+type Timeline struct {
+	mu        sync.RWMutex
+	anchor    Anchor
+	threshold Duration
+}
+
+// NowAnchor samples wall clock and monotonic clock at the same instant
+func NowAnchor() Anchor {
+	now := time.Now()
+
+	return Anchor{Wall: wallFromTime(now), Clock: clockFromTime(&now)}
+}
+
+// ToTime estimates wall clock time at which monotonic clock read c
+func (a Anchor) ToTime(c ClockTime) Time {
+	return a.Wall.Add(c.Sub(a.Clock))
+}
+
+// ToClock estimates monotonic clock reading at wall clock time t
+func (a Anchor) ToClock(t Time) ClockTime {
+	return a.Clock.Add(t.Sub(a.Wall))
+}
+
+/*
+Skew returns how far wall clock of o is from the wall clock estimated using a.
+
+It is zero when both anchors agree, positive if wall clock of o is ahead.
+*/
+func (a Anchor) Skew(o Anchor) Duration {
+	return o.Wall.Sub(a.ToTime(o.Clock))
+}
+
+/*
+NewTimeline creates a Timeline anchored at the current instant.
+
+Wall clock changes with absolute value above threshold are reported as jumps.
+Absolute value of threshold is used.
+*/
+func NewTimeline(threshold Duration) *Timeline {
+	return NewTimelineAt(NowAnchor(), threshold)
+}
+
+// NewTimelineAt creates a Timeline anchored at the given anchor
+func NewTimelineAt(anchor Anchor, threshold Duration) *Timeline {
+	return &Timeline{anchor: anchor, threshold: threshold.Abs()}
+}
+
+// Anchor returns the anchor currently used for conversions
+func (tl *Timeline) Anchor() Anchor {
+	tl.mu.RLock()
+	defer tl.mu.RUnlock()
+
+	return tl.anchor
+}
+
+// ToTime estimates wall clock time at which monotonic clock read c
+func (tl *Timeline) ToTime(c ClockTime) Time {
+	return tl.Anchor().ToTime(c)
+}
+
+// ToClock estimates monotonic clock reading at wall clock time t
+func (tl *Timeline) ToClock(t Time) ClockTime {
+	return tl.Anchor().ToClock(t)
+}
+
+// Check samples the clocks and re-anchors the timeline if wall clock has jumped
+func (tl *Timeline) Check() (Jump, bool) {
+	return tl.Observe(NowAnchor())
+}
+
+/*
+Observe compares sample against the current anchor.
+
+If the skew exceeds the threshold, sample becomes the new anchor and the jump
+is returned.
+*/
+func (tl *Timeline) Observe(sample Anchor) (Jump, bool) {
+	tl.mu.Lock()
+	defer tl.mu.Unlock()
+
+	skew := tl.anchor.Skew(sample)
+	if skew.Abs() <= tl.threshold {
+		return Jump{}, false
+	}
+
+	jump := Jump{Previous: tl.anchor, Current: sample, Magnitude: skew}
+	tl.anchor = sample
+
+	return jump, true
+}
+
+// Reanchor unconditionally replaces the anchor with a fresh sample
+func (tl *Timeline) Reanchor() Anchor {
+	sample := NowAnchor()
+
+	tl.mu.Lock()
+	tl.anchor = sample
+	tl.mu.Unlock()
+
+	return sample
+}
@@ -0,0 +1,145 @@
+package datetime_test
+
+import (
+	"testing"
+
+	"github.com/ram-nad/go-utils/datetime"
+)
+
+func TestNowAnchor(t *testing.T) {
+	before := datetime.Now()
+	anchor := datetime.NowAnchor()
+	after := datetime.Now()
+
+	if anchor.Wall.Before(before) || anchor.Wall.After(after) {
+		t.Errorf(
+			"Expected anchor wall %v between %v and %v",
+			anchor.Wall,
+			before,
+			after,
+		)
+	}
+
+	if anchor.Clock > datetime.NowClock() {
+		t.Errorf("Expected anchor clock %v to be in the past", anchor.Clock)
+	}
+}
+
+func TestAnchorConversion(t *testing.T) {
+	anchor := datetime.Anchor{
+		Wall:  datetime.Date(2021, 1, 1, 0, 0, 0, 0),
+		Clock: datetime.ClockTime(datetime.Seconds(100)),
+	}
+
+	c := anchor.Clock.Add(datetime.Minutes(90))
+	expected := datetime.Date(2021, 1, 1, 1, 30, 0, 0)
+	if got := anchor.ToTime(c); !got.Equal(expected) {
+		t.Errorf("ToTime(%v) = %v, want %v", c, got, expected)
+	}
+
+	if got := anchor.ToClock(expected); got != c {
+		t.Errorf("ToClock(%v) = %v, want %v", expected, got, c)
+	}
+
+	past := datetime.ClockTime(datetime.Seconds(40))
+	expected = datetime.Date(2020, 12, 31, 23, 59, 0, 0)
+	if got := anchor.ToTime(past); !got.Equal(expected) {
+		t.Errorf("ToTime(%v) = %v, want %v", past, got, expected)
+	}
+}
+
+func TestAnchorSkew(t *testing.T) {
+	anchor := datetime.Anchor{
+		Wall:  datetime.Date(2021, 1, 1, 0, 0, 0, 0),
+		Clock: datetime.ClockTime(0),
+	}
+
+	sample := datetime.Anchor{
+		Wall:  datetime.Date(2021, 1, 1, 0, 0, 15, 0),
+		Clock: datetime.ClockTime(datetime.Seconds(10)),
+	}
+
+	if got := anchor.Skew(sample); got != datetime.Seconds(5) {
+		t.Errorf("Skew = %v, want 5s", got)
+	}
+
+	if got := sample.Skew(anchor); got != datetime.Seconds(-5) {
+		t.Errorf("Skew = %v, want -5s", got)
+	}
+}
+
+func TestTimelineObserve(t *testing.T) {
+	initial := datetime.Anchor{
+		Wall:  datetime.Date(2021, 1, 1, 0, 0, 0, 0),
+		Clock: datetime.ClockTime(0),
+	}
+	timeline := datetime.NewTimelineAt(initial, datetime.Seconds(-1))
+
+	t.Run("WithinThreshold", func(t *testing.T) {
+		sample := datetime.Anchor{
+			Wall:  datetime.Date(2021, 1, 1, 0, 1, 0, 500_000_000),
+			Clock: datetime.ClockTime(datetime.Minutes(1)),
+		}
+
+		if jump, ok := timeline.Observe(sample); ok {
+			t.Errorf("Expected no jump, got %+v", jump)
+		}
+
+		if timeline.Anchor() != initial {
+			t.Errorf("Expected anchor to be unchanged, got %+v", timeline.Anchor())
+		}
+	})
+
+	t.Run("BackwardJump", func(t *testing.T) {
+		sample := datetime.Anchor{
+			Wall:  datetime.Date(2021, 1, 1, 0, 0, 0, 0),
+			Clock: datetime.ClockTime(datetime.Minutes(2)),
+		}
+
+		jump, ok := timeline.Observe(sample)
+		if !ok {
+			t.Fatalf("Expected jump to be detected")
+		}
+
+		if jump.Magnitude != datetime.Minutes(-2) {
+			t.Errorf("Expected magnitude -2m, got %v", jump.Magnitude)
+		}
+
+		if jump.Previous != initial || jump.Current != sample {
+			t.Errorf("Unexpected anchors in jump %+v", jump)
+		}
+
+		if timeline.Anchor() != sample {
+			t.Errorf("Expected timeline to be re-anchored, got %+v", timeline.Anchor())
+		}
+
+		c := datetime.ClockTime(datetime.Minutes(3))
+		expected := datetime.Date(2021, 1, 1, 0, 1, 0, 0)
+		if got := timeline.ToTime(c); !got.Equal(expected) {
+			t.Errorf("ToTime(%v) = %v, want %v", c, got, expected)
+		}
+
+		if got := timeline.ToClock(expected); got != c {
+			t.Errorf("ToClock(%v) = %v, want %v", expected, got, c)
+		}
+	})
+}
+
+func TestTimelineCheck(t *testing.T) {
+	timeline := datetime.NewTimeline(datetime.Hours(1))
+
+	if jump, ok := timeline.Check(); ok {
+		t.Errorf("Expected no jump, got %+v", jump)
+	}
+
+	anchor := timeline.Reanchor()
+	if timeline.Anchor() != anchor {
+		t.Errorf("Expected Reanchor to replace the anchor")
+	}
+
+	start := datetime.NowClock()
+	estimate := timeline.ToTime(start)
+	if d := datetime.Since(estimate).Abs(); d > datetime.Seconds(1) {
+		t.Errorf("Expected estimate %v to be close to now, off by %v", estimate, d)
+	}
+}
@@ -0,0 +1,390 @@
+/*
+Package timingwheel schedules large numbers of timeouts on a hierarchical timing wheel.
+
+Time is divided into ticks of fixed Duration. Level 0 has a slot for each of the next
+64 ticks, each higher level has 64 slots covering a whole rotation of the level below.
+A timer is linked into the slot of the lowest level covering its deadline, and moves
+down a level when the wheel reaches that slot:
+
+	level 0    64 slots of 1 tick
+	level 1    64 slots of 64 ticks
+	level 2    64 slots of 4096 ticks
+
+Adding, stopping and resetting a timer are O(1), independent of the number of timers.
+Timers fire on the first tick at or after their deadline, so up to one tick late.
+Advance jumps over ticks with nothing to fire or cascade, using a bitmap of occupied
+slots per level, so a large jump costs as much as the slots it passes with timers.
+Deadlines beyond the last level are parked in its farthest slot until they are near.
+
+The wheel has no goroutine of its own, time only moves with Advance, or with Run which
+calls Advance on every tick. Values of timers due in one Advance are passed to the
+expiry callback together:
+
+	wheel := timingwheel.New(datetime.NowClock(), datetime.Milliseconds(10), 4,
+		func(conns []*Conn) {
+			for _, c := range conns {
+				c.Close()
+			}
+		})
+	go wheel.Run(ctx)
+
+	timer := wheel.Add(datetime.NowClock().Add(idleTimeout), conn)
+	...
+	timer.Reset(datetime.NowClock().Add(idleTimeout))
+*/
+package timingwheel
+
+import (
+	"context"
+	"math"
+	"math/bits"
+	"sync"
+	"time"
+
+	"github.com/ram-nad/go-utils/datetime"
+)
+
+// Wheel schedules timers carrying values of type T, it is safe for concurrent use
+type Wheel[T any] struct {
+	mu     sync.Mutex
+	start  datetime.ClockTime
+	tick   datetime.Duration
+	levels [][slotsPerLevel]bucket[T]
+	// occupied has a bit for each non-empty slot of a level
+	occupied []uint64
+	// span is number of ticks covered by all levels
+	span uint64
+	// now is the last tick processed
+	now    uint64
+	count  int
+	expire func([]T)
+}
+
+// Timer is a scheduled value, it can be stopped or reset until it fires
+type Timer[T any] struct {
+	wheel    *Wheel[T]
+	value    T
+	deadline datetime.ClockTime
+	// at is the tick the timer fires on
+	at uint64
+	// bucket is the slot the timer is linked into, nil if the timer isn't pending
+	bucket     *bucket[T]
+	prev, next *Timer[T]
+}
+
+// bucket is a doubly linked list of timers in a slot
+type bucket[T any] struct {
+	head *Timer[T]
+	// occupied is the bitmap of the level, bit is the one of this slot
+	occupied *uint64
+	bit      uint64
+}
+
+const (
+	bitsPerLevel  = 6
+	slotsPerLevel = 1 << bitsPerLevel
+	slotMask      = slotsPerLevel - 1
+	// MaxLevels is the largest number of levels, covering 2^60 ticks
+	MaxLevels = 10
+)
+
+/*
+New creates a wheel starting at start, with the given tick and number of levels.
+
+Levels cover 64^levels ticks together, later deadlines are still correct but are
+rescheduled every rotation of the last level. expire is called with values of timers
+due in an Advance, without holding any lock, so it may add and reset timers.
+
+New panics if tick is not positive or levels is not in [1, MaxLevels].
+*/
+func New[T any](
+	start datetime.ClockTime,
+	tick datetime.Duration,
+	levels int,
+	expire func([]T),
+) *Wheel[T] {
+	if tick <= 0 {
+		panic("timingwheel: non-positive tick for New")
+	}
+
+	if levels < 1 || levels > MaxLevels {
+		panic("timingwheel: invalid number of levels for New")
+	}
+
+	w := &Wheel[T]{
+		start:    start,
+		tick:     tick,
+		levels:   make([][slotsPerLevel]bucket[T], levels),
+		occupied: make([]uint64, levels),
+		span:     1 << (bitsPerLevel * levels),
+		expire:   expire,
+	}
+
+	for level := range w.levels {
+		for slot := range w.levels[level] {
+			b := &w.levels[level][slot]
+			b.occupied, b.bit = &w.occupied[level], 1<<slot
+		}
+	}
+
+	return w
+}
+
+// Tick returns the resolution of the wheel
+func (w *Wheel[T]) Tick() datetime.Duration {
+	return w.tick
+}
+
+// Len returns the number of pending timers
+func (w *Wheel[T]) Len() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.count
+}
+
+// Add schedules v to expire at deadline, a deadline in the past expires on next tick
+func (w *Wheel[T]) Add(deadline datetime.ClockTime, v T) *Timer[T] {
+	t := &Timer[T]{wheel: w, value: v}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.schedule(t, deadline)
+
+	return t
+}
+
+// schedule sets deadline of the timer and links it, t must not be pending
+func (w *Wheel[T]) schedule(t *Timer[T], deadline datetime.ClockTime) {
+	t.deadline = deadline
+	t.at = w.now + 1
+
+	// Tick on or after the deadline
+	if elapsed := deadline.Sub(w.start); elapsed > 0 {
+		at := uint64(elapsed / w.tick)
+		if elapsed%w.tick != 0 {
+			at++
+		}
+		t.at = max(at, t.at)
+	}
+
+	w.count++
+	w.link(t, w.now)
+}
+
+// link puts t into the slot for its tick as seen from tick base, t.at >= base
+func (w *Wheel[T]) link(t *Timer[T], base uint64) {
+	at := t.at
+	if at-base >= w.span {
+		at = base + w.span - 1
+	}
+
+	level := 0
+	for at-base >= 1<<(bitsPerLevel*(level+1)) {
+		level++
+	}
+
+	b := &w.levels[level][(at>>(bitsPerLevel*level))&slotMask]
+	t.bucket, t.prev, t.next = b, nil, b.head
+	if b.head != nil {
+		b.head.prev = t
+	}
+	b.head = t
+	*b.occupied |= b.bit
+}
+
+// unlink removes t from its slot
+func (t *Timer[T]) unlink() {
+	if t.prev != nil {
+		t.prev.next = t.next
+	} else if t.bucket.head = t.next; t.next == nil {
+		*t.bucket.occupied &^= t.bucket.bit
+	}
+
+	if t.next != nil {
+		t.next.prev = t.prev
+	}
+
+	t.bucket, t.prev, t.next = nil, nil, nil
+}
+
+// detach empties the bucket and returns its timers as a list
+func (b *bucket[T]) detach() *Timer[T] {
+	head := b.head
+	b.head = nil
+	*b.occupied &^= b.bit
+	return head
+}
+
+/*
+Advance moves the wheel to now and expires timers due by then, returning how many did.
+
+Due values are passed to the expiry callback in order of their ticks, in a single call.
+Times before the last Advance are ignored.
+*/
+func (w *Wheel[T]) Advance(now datetime.ClockTime) int {
+	expired := w.advance(now)
+	if len(expired) > 0 {
+		w.expire(expired)
+	}
+
+	return len(expired)
+}
+
+func (w *Wheel[T]) advance(now datetime.ClockTime) []T {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	elapsed := now.Sub(w.start)
+	if elapsed < 0 {
+		return nil
+	}
+
+	target := uint64(elapsed / w.tick)
+	if w.count == 0 {
+		w.now = max(w.now, target)
+		return nil
+	}
+
+	var expired []T
+	for w.count > 0 {
+		next := w.next()
+		if next > target {
+			break
+		}
+
+		w.now = next
+		w.cascade()
+		expired = w.fire(expired)
+	}
+	w.now = max(w.now, target)
+
+	return expired
+}
+
+// next returns the first tick after now which fires or cascades a non-empty slot
+func (w *Wheel[T]) next() uint64 {
+	next := uint64(math.MaxUint64)
+
+	for level, occupied := range w.occupied {
+		if occupied == 0 {
+			continue
+		}
+
+		// Slots of a level are reached on multiples of its slot size, in order
+		shift := bitsPerLevel * level
+		first := w.now>>shift + 1
+		rotated := bits.RotateLeft64(occupied, -int(first&slotMask))
+		at := (first + uint64(bits.TrailingZeros64(rotated))) << shift
+		next = min(next, at)
+	}
+
+	return next
+}
+
+// cascade moves timers of slots reached at the current tick to lower levels
+func (w *Wheel[T]) cascade() {
+	// Highest level whose slot starts at this tick
+	top := 0
+	for top+1 < len(w.levels) && w.now%(1<<(bitsPerLevel*(top+1))) == 0 {
+		top++
+	}
+
+	for level := top; level > 0; level-- {
+		b := &w.levels[level][(w.now>>(bitsPerLevel*level))&slotMask]
+		for t := b.detach(); t != nil; {
+			next := t.next
+			w.link(t, w.now)
+			t = next
+		}
+	}
+}
+
+// fire removes timers due at the current tick and appends their values to expired
+func (w *Wheel[T]) fire(expired []T) []T {
+	b := &w.levels[0][w.now&slotMask]
+	for t := b.detach(); t != nil; {
+		next := t.next
+		if t.at > w.now {
+			// Parked in the farthest slot of a single level wheel
+			w.link(t, w.now)
+		} else {
+			t.bucket, t.prev, t.next = nil, nil, nil
+			w.count--
+			expired = append(expired, t.value)
+		}
+		t = next
+	}
+
+	return expired
+}
+
+// Run advances the wheel on every tick until ctx is done
+func (w *Wheel[T]) Run(ctx context.Context) {
+	ticker := time.NewTicker(time.Duration(w.tick))
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.Advance(datetime.NowClock())
+		}
+	}
+}
+
+// Value returns the value the timer carries
+func (t *Timer[T]) Value() T {
+	return t.value
+}
+
+// Deadline returns the time the timer is scheduled for
+func (t *Timer[T]) Deadline() datetime.ClockTime {
+	t.wheel.mu.Lock()
+	defer t.wheel.mu.Unlock()
+
+	return t.deadline
+}
+
+// Pending reports whether the timer has neither fired nor been stopped
+func (t *Timer[T]) Pending() bool {
+	t.wheel.mu.Lock()
+	defer t.wheel.mu.Unlock()
+
+	return t.bucket != nil
+}
+
+// Stop cancels the timer, it returns false if the timer already fired or was stopped
+func (t *Timer[T]) Stop() bool {
+	t.wheel.mu.Lock()
+	defer t.wheel.mu.Unlock()
+
+	if t.bucket == nil {
+		return false
+	}
+
+	t.unlink()
+	t.wheel.count--
+
+	return true
+}
+
+// Reset schedules the timer for deadline, it reports whether the timer was pending
+func (t *Timer[T]) Reset(deadline datetime.ClockTime) bool {
+	w := t.wheel
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	pending := t.bucket != nil
+	if pending {
+		t.unlink()
+		w.count--
+	}
+
+	w.schedule(t, deadline)
+
+	return pending
+}
@@ -0,0 +1,369 @@
+package timingwheel_test
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ram-nad/go-utils/datetime"
+	"github.com/ram-nad/go-utils/datetime/timingwheel"
+)
+
+const start = datetime.ClockTime(1_000_000_000)
+
+// recorder collects expired values with the time they expired at
+type recorder struct {
+	now   datetime.ClockTime
+	fired map[int]datetime.ClockTime
+	calls int
+}
+
+func newRecorder() *recorder {
+	return &recorder{now: start, fired: make(map[int]datetime.ClockTime)}
+}
+
+func (r *recorder) expire(values []int) {
+	r.calls++
+	for _, v := range values {
+		r.fired[v] = r.now
+	}
+}
+
+// advance moves the wheel to now
+func (r *recorder) advance(w *timingwheel.Wheel[int], now datetime.ClockTime) int {
+	r.now = now
+	return w.Advance(now)
+}
+
+func TestWheelFiresOnFirstTickAfterDeadline(t *testing.T) {
+	tick := datetime.Milliseconds(1)
+	rng := rand.New(rand.NewPCG(1, 2))
+
+	for _, levels := range []int{1, 2, 3} {
+		r := newRecorder()
+		w := timingwheel.New(start, tick, levels, r.expire)
+
+		deadlines := make(map[int]datetime.ClockTime)
+		for i := range 5000 {
+			delay := datetime.Duration(rng.Int64N(int64(tick) * 300_000))
+			deadlines[i] = start.Add(delay)
+			w.Add(deadlines[i], i)
+		}
+
+		if w.Len() != len(deadlines) {
+			t.Fatalf("Len() = %v, want %v", w.Len(), len(deadlines))
+		}
+
+		for now := start; w.Len() > 0; now = now.Add(tick) {
+			r.advance(w, now)
+		}
+
+		for i, deadline := range deadlines {
+			fired, ok := r.fired[i]
+			if !ok || fired < deadline || fired.Sub(deadline) >= tick {
+				t.Fatalf(
+					"levels %d: timer %d with deadline %v fired at %v, %v",
+					levels, i, deadline, fired, ok,
+				)
+			}
+		}
+	}
+}
+
+func TestWheelAdvanceManyTicks(t *testing.T) {
+	r := newRecorder()
+	w := timingwheel.New(start, datetime.Seconds(1), 3, r.expire)
+
+	w.Add(start.Add(datetime.Hours(2)), 1)
+	w.Add(start.Add(datetime.Seconds(90)), 2)
+	w.Add(start.Add(datetime.Hours(100)), 3)
+
+	if n := r.advance(w, start.Add(datetime.Hours(3))); n != 2 || r.calls != 1 {
+		t.Errorf("Advance() = %v with %v calls, want 2 with 1 call", n, r.calls)
+	}
+
+	if n := r.advance(w, start.Add(datetime.Hours(100))); n != 1 {
+		t.Errorf("Advance() = %v, want 1", n)
+	}
+
+	if w.Len() != 0 {
+		t.Errorf("Len() = %v, want 0", w.Len())
+	}
+}
+
+func TestWheelLargeJumps(t *testing.T) {
+	tick := datetime.Milliseconds(1)
+	rng := rand.New(rand.NewPCG(3, 4))
+
+	for _, levels := range []int{2, 4} {
+		r := newRecorder()
+		w := timingwheel.New(start, tick, levels, r.expire)
+
+		deadlines := make(map[int]datetime.ClockTime)
+		for i := range 2000 {
+			deadlines[i] = start.Add(datetime.Milliseconds(rng.Int64N(7_200_000)))
+			w.Add(deadlines[i], i)
+		}
+
+		// Timers fire in the first Advance at or after their deadline
+		previous := start
+		for now := start; w.Len() > 0; previous = now {
+			now = now.Add(datetime.Milliseconds(rng.Int64N(600_000)))
+			r.advance(w, now)
+
+			for i, deadline := range deadlines {
+				fired, ok := r.fired[i]
+				late := deadline > previous && ok && fired != now
+				if ok != (deadline <= now) || late {
+					t.Fatalf(
+						"levels %d: timer %d with deadline %v fired at %v, %v",
+						levels, i, deadline, fired, ok,
+					)
+				}
+			}
+		}
+	}
+}
+
+func TestWheelStop(t *testing.T) {
+	r := newRecorder()
+	w := timingwheel.New(start, datetime.Milliseconds(10), 2, r.expire)
+
+	timers := make([]*timingwheel.Timer[int], 100)
+	for i := range timers {
+		timers[i] = w.Add(start.Add(datetime.Seconds(int64(i))), i)
+	}
+
+	for i := 0; i < len(timers); i += 2 {
+		if !timers[i].Stop() {
+			t.Errorf("Stop() = false for pending timer %d", i)
+		}
+
+		if timers[i].Stop() || timers[i].Pending() {
+			t.Errorf("Stop() = true for stopped timer %d", i)
+		}
+	}
+
+	r.advance(w, start.Add(datetime.Minutes(5)))
+
+	for i, timer := range timers {
+		if _, fired := r.fired[i]; fired != (i%2 == 1) {
+			t.Errorf("timer %d fired = %v", i, fired)
+		}
+
+		if timer.Stop() {
+			t.Errorf("Stop() = true for fired timer %d", i)
+		}
+	}
+}
+
+func TestWheelReset(t *testing.T) {
+	r := newRecorder()
+	tick := datetime.Milliseconds(10)
+	w := timingwheel.New(start, tick, 3, r.expire)
+
+	timer := w.Add(start.Add(datetime.Seconds(1)), 1)
+	r.advance(w, start.Add(datetime.Milliseconds(500)))
+
+	deadline := start.Add(datetime.Minutes(2))
+	if !timer.Reset(deadline) || timer.Deadline() != deadline {
+		t.Fatalf("Reset() = false, deadline %v", timer.Deadline())
+	}
+
+	r.advance(w, start.Add(datetime.Seconds(1)))
+	if len(r.fired) != 0 {
+		t.Fatalf("timer fired at %v before reset deadline", r.fired[1])
+	}
+
+	for now := r.now; len(r.fired) == 0; now = now.Add(tick) {
+		r.advance(w, now)
+	}
+
+	if r.fired[1] != deadline {
+		t.Errorf("timer fired at %v, want %v", r.fired[1], deadline)
+	}
+
+	// Fired timers can be reused
+	if timer.Reset(start) {
+		t.Errorf("Reset() = true for fired timer")
+	}
+
+	if r.advance(w, r.now.Add(tick)) != 1 {
+		t.Errorf("timer reset to the past didn't fire on next tick")
+	}
+}
+
+func TestWheelPastDeadline(t *testing.T) {
+	r := newRecorder()
+	tick := datetime.Milliseconds(10)
+	w := timingwheel.New(start, tick, 2, r.expire)
+
+	r.advance(w, start.Add(datetime.Seconds(1)))
+	w.Add(start, 1)
+	w.Add(start.Add(-datetime.Hours(1)), 2)
+
+	if n := r.advance(w, r.now); n != 0 {
+		t.Errorf("Advance() to the same time = %v, want 0", n)
+	}
+
+	if n := r.advance(w, r.now.Add(tick)); n != 2 {
+		t.Errorf("Advance() = %v, want 2", n)
+	}
+}
+
+func TestWheelExpireCanAdd(t *testing.T) {
+	var w *timingwheel.Wheel[int]
+
+	rounds := 0
+	w = timingwheel.New(start, datetime.Seconds(1), 2, func(values []int) {
+		rounds++
+		for _, v := range values {
+			if v > 0 {
+				w.Add(start.Add(datetime.Seconds(int64(rounds))), v-1)
+			}
+		}
+	})
+
+	w.Add(start, 3)
+	for i := range int64(10) {
+		w.Advance(start.Add(datetime.Seconds(i)))
+	}
+
+	if rounds != 4 {
+		t.Errorf("expire called %v times, want 4", rounds)
+	}
+}
+
+func TestWheelRun(t *testing.T) {
+	var wg sync.WaitGroup
+	wg.Add(1)
+
+	w := timingwheel.New(datetime.NowClock(), datetime.Milliseconds(1), 2, func([]int) {
+		wg.Done()
+	})
+	w.Add(datetime.NowClock().Add(datetime.Milliseconds(5)), 1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	go w.Run(ctx)
+	wg.Wait()
+}
+
+func TestNewPanics(t *testing.T) {
+	tests := []struct {
+		tick   datetime.Duration
+		levels int
+	}{
+		{0, 1},
+		{-1, 1},
+		{1, 0},
+		{1, timingwheel.MaxLevels + 1},
+	}
+
+	for _, tc := range tests {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("New(%v, %v) didn't panic", tc.tick, tc.levels)
+				}
+			}()
+			timingwheel.New(start, tc.tick, tc.levels, func([]int) {})
+		}()
+	}
+}
+
+// benchmarkTimers is number of timers in benchmarks
+const benchmarkTimers = 1_000_000
+
+// benchmarkDelays returns random delays up to a minute
+func benchmarkDelays() []datetime.Duration {
+	rng := rand.New(rand.NewPCG(1, 2))
+
+	delays := make([]datetime.Duration, benchmarkTimers)
+	for i := range delays {
+		delays[i] = datetime.Duration(rng.Int64N(int64(time.Minute)))
+	}
+
+	return delays
+}
+
+func BenchmarkWheel(b *testing.B) {
+	delays := benchmarkDelays()
+	timers := make([]*timingwheel.Timer[int], benchmarkTimers)
+
+	for b.Loop() {
+		now := datetime.NowClock()
+		w := timingwheel.New(now, datetime.Milliseconds(1), 4, func([]int) {})
+
+		for i, d := range delays {
+			timers[i] = w.Add(now.Add(d), i)
+		}
+
+		for i, timer := range timers {
+			timer.Reset(now.Add(delays[len(delays)-1-i]))
+		}
+
+		for _, timer := range timers {
+			timer.Stop()
+		}
+	}
+}
+
+func BenchmarkWheelLargeJump(b *testing.B) {
+	for b.Loop() {
+		w := timingwheel.New(start, datetime.Milliseconds(1), 4, func([]int) {})
+		w.Add(start.Add(datetime.Hours(2)), 1)
+
+		// Suspended for an hour, nothing is due
+		w.Advance(start.Add(datetime.Hours(1)))
+		if w.Advance(start.Add(datetime.Hours(2))) != 1 {
+			b.Fatal("timer didn't fire")
+		}
+	}
+}
+
+func BenchmarkAfterFunc(b *testing.B) {
+	delays := benchmarkDelays()
+	timers := make([]*time.Timer, benchmarkTimers)
+
+	for b.Loop() {
+		for i, d := range delays {
+			timers[i] = time.AfterFunc(time.Duration(d), func() {})
+		}
+
+		for i, timer := range timers {
+			timer.Reset(time.Duration(delays[len(delays)-1-i]))
+		}
+
+		for _, timer := range timers {
+			timer.Stop()
+		}
+	}
+}
+
+func ExampleWheel() {
+	start := datetime.NowClock()
+	w := timingwheel.New(start, datetime.Seconds(1), 3, func(conns []string) {
+		_, _ = fmt.Println("closing", conns)
+	})
+
+	w.Add(start.Add(datetime.Seconds(30)), "a")
+	idle := w.Add(start.Add(datetime.Seconds(30)), "b")
+	w.Add(start.Add(datetime.Minutes(5)), "c")
+
+	// Activity on b pushes its timeout back
+	idle.Reset(start.Add(datetime.Seconds(60)))
+
+	for i := range int64(90) {
+		w.Advance(start.Add(datetime.Seconds(i + 1)))
+	}
+	_, _ = fmt.Println("pending", w.Len())
+	// Output:
+	// closing [a]
+	// closing [b]
+	// pending 1
+}
@@ -0,0 +1,220 @@
+package window
+
+/*
+Aggregator tracks a watermark, the time up to which all events are assumed to have
+arrived. The watermark trails the latest event time by the allowed lateness:
+
+	watermark = max(event time) - lateness
+
+A window is closed and emitted once its End is not after the watermark. Events which
+only belong to closed windows are dropped and counted, events never reopen a window
+that was already emitted.
+
+For Merging assigners the window of a new event is merged with every open window it
+overlaps, values are combined with reduce in order of window start.
+*/
+
+import (
+	"slices"
+	"sync"
+
+	"github.com/ram-nad/go-utils/datetime"
+)
+
+// Result is the aggregated value of a window
+type Result[T any] struct {
+	Window Window
+	Value  T
+	// Count is number of events aggregated into Value
+	Count int
+}
+
+/*
+windowKey identifies a window by its instants. Window itself isn't a good key, Time
+compares equal only with the same Location and monotonic clock reading.
+*/
+type windowKey struct {
+	startSec  int64
+	startNsec int32
+	endSec    int64
+	endNsec   int32
+}
+
+// Aggregator accumulates values per window and emits closed windows, it is safe for
+// concurrent use
+type Aggregator[T any] struct {
+	mu        sync.Mutex
+	assigner  Assigner
+	merging   bool
+	lateness  datetime.Duration
+	reduce    func(acc, v T) T
+	open      map[windowKey]*Result[T]
+	watermark datetime.Time
+	dropped   int
+}
+
+/*
+NewAggregator creates aggregator assigning events with assigner and combining values
+with reduce. Windows are emitted once the latest event time is lateness past their end.
+
+For Merging assigners reduce is also used to combine accumulated values of two
+windows, so it must be associative.
+*/
+func NewAggregator[T any](
+	assigner Assigner,
+	lateness datetime.Duration,
+	reduce func(acc, v T) T,
+) *Aggregator[T] {
+	_, merging := assigner.(Merging)
+
+	return &Aggregator[T]{
+		assigner: assigner,
+		merging:  merging,
+		lateness: lateness,
+		reduce:   reduce,
+		open:     make(map[windowKey]*Result[T]),
+	}
+}
+
+// Add aggregates value v observed at t, returns windows closed by advancing watermark
+func (a *Aggregator[T]) Add(t datetime.Time, v T) []Result[T] {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	accepted := false
+	for _, w := range a.assigner.Assign(t) {
+		if !w.End.After(a.watermark) {
+			continue
+		}
+
+		accepted = true
+		if a.merging {
+			a.merge(Result[T]{Window: w, Value: v, Count: 1})
+		} else if r, ok := a.open[keyOf(w)]; ok {
+			r.Value = a.reduce(r.Value, v)
+			r.Count++
+		} else {
+			a.open[keyOf(w)] = &Result[T]{Window: w, Value: v, Count: 1}
+		}
+	}
+
+	if !accepted {
+		a.dropped++
+	}
+
+	return a.advance(t.Add(-a.lateness))
+}
+
+// merge combines r with all open windows overlapping it
+func (a *Aggregator[T]) merge(r Result[T]) {
+	parts := []Result[T]{r}
+	for k, o := range a.open {
+		if o.Window.Overlaps(r.Window) {
+			parts = append(parts, *o)
+			delete(a.open, k)
+		}
+	}
+
+	slices.SortFunc(parts, compareResults)
+
+	merged := parts[0]
+	for _, p := range parts[1:] {
+		merged.Value = a.reduce(merged.Value, p.Value)
+		merged.Count += p.Count
+		if p.Window.End.After(merged.Window.End) {
+			merged.Window.End = p.Window.End
+		}
+	}
+
+	a.open[keyOf(merged.Window)] = &merged
+}
+
+/*
+Advance moves the watermark to t and returns windows closed by it.
+
+Use it to close windows when no events arrive, for example from a ticker. The
+watermark never moves backwards.
+*/
+func (a *Aggregator[T]) Advance(t datetime.Time) []Result[T] {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.advance(t)
+}
+
+func (a *Aggregator[T]) advance(t datetime.Time) []Result[T] {
+	if t.After(a.watermark) {
+		a.watermark = t
+	}
+
+	var closed []Result[T]
+	for k, r := range a.open {
+		if !r.Window.End.After(a.watermark) {
+			closed = append(closed, *r)
+			delete(a.open, k)
+		}
+	}
+
+	slices.SortFunc(closed, compareResults)
+	return closed
+}
+
+// Flush returns all open windows without moving the watermark
+func (a *Aggregator[T]) Flush() []Result[T] {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	closed := make([]Result[T], 0, len(a.open))
+	for _, r := range a.open {
+		closed = append(closed, *r)
+	}
+	clear(a.open)
+
+	slices.SortFunc(closed, compareResults)
+	return closed
+}
+
+// Watermark returns time up to which windows have been closed
+func (a *Aggregator[T]) Watermark() datetime.Time {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.watermark
+}
+
+// Dropped returns number of events discarded because all their windows were closed
+func (a *Aggregator[T]) Dropped() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.dropped
+}
+
+// keyOf returns the key of w in open windows
+func keyOf(w Window) windowKey {
+	return windowKey{
+		startSec:  w.Start.Unix(),
+		startNsec: int32(w.Start.Nanosecond()), //nolint:gosec // below 1e9
+		endSec:    w.End.Unix(),
+		endNsec:   int32(w.End.Nanosecond()), //nolint:gosec // below 1e9
+	}
+}
+
+// compareResults orders results by window start, then by window end
+func compareResults[T any](x, y Result[T]) int {
+	if c := compareTimes(x.Window.Start, y.Window.Start); c != 0 {
+		return c
+	}
+	return compareTimes(x.Window.End, y.Window.End)
+}
+
+func compareTimes(x, y datetime.Time) int {
+	switch {
+	case x.Before(y):
+		return -1
+	case x.After(y):
+		return 1
+	default:
+		return 0
+	}
+}
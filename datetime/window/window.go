@@ -0,0 +1,188 @@
+/*
+Package window assigns timestamps to time windows and aggregates values per window.
+
+Windows are half-open intervals [Start, End) of datetime.Time. An Assigner maps an
+event time to the windows it belongs to:
+
+	Tumbling   fixed size, non-overlapping windows
+	Hopping    fixed size windows starting every Slide, overlapping when Slide < Size
+	Session    windows of activity separated by gaps of inactivity, merged by Aggregator
+	Calendar   calendar periods like months or ISO weeks
+
+Example:
+
+	perMinute := window.NewTumbling(datetime.Minutes(1), 0)
+	sum := func(acc, v int) int { return acc + v }
+	agg := window.NewAggregator(perMinute, datetime.Seconds(10), sum)
+	for _, result := range agg.Add(eventTime, 1) {
+		fmt.Printf("%v: %d events\n", result.Window, result.Value)
+	}
+*/
+package window
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ram-nad/go-utils/datetime"
+)
+
+// Window is the half-open time interval [Start, End)
+type Window struct {
+	Start datetime.Time
+	End   datetime.Time
+}
+
+// Assigner maps an event time to windows containing it
+type Assigner interface {
+	// Assign returns windows containing t, ordered by start
+	Assign(t datetime.Time) []Window
+}
+
+// Merging is implemented by assigners whose overlapping windows are merged
+type Merging interface {
+	Assigner
+	merging()
+}
+
+// Tumbling assigns each time to exactly one window of fixed size
+type Tumbling struct {
+	size   datetime.Duration
+	offset datetime.Duration
+}
+
+// Hopping assigns each time to windows of fixed size starting every slide
+type Hopping struct {
+	size   datetime.Duration
+	slide  datetime.Duration
+	offset datetime.Duration
+}
+
+// Session assigns each time to window [t, t+gap), overlapping windows are merged
+type Session struct {
+	gap datetime.Duration
+}
+
+// Calendar assigns each time to the calendar period containing it
+type Calendar struct {
+	unit      datetime.CalendarUnit
+	weekStart datetime.Weekday
+}
+
+const daysPerWeek = 7
+
+// Contains reports whether t is within the window
+func (w Window) Contains(t datetime.Time) bool {
+	return !t.Before(w.Start) && t.Before(w.End)
+}
+
+// Duration returns length of the window
+func (w Window) Duration() datetime.Duration {
+	return w.End.Sub(w.Start)
+}
+
+// Overlaps reports whether windows share any instant
+func (w Window) Overlaps(o Window) bool {
+	return w.Start.Before(o.End) && o.Start.Before(w.End)
+}
+
+// String returns the window in interval notation
+func (w Window) String() string {
+	return fmt.Sprintf("[%s, %s)", w.Start.ISOStringNano(), w.End.ISOStringNano())
+}
+
+// alignedStart returns start of the window of given size containing t
+func alignedStart(t datetime.Time, size, offset datetime.Duration) datetime.Time {
+	return t.Add(-offset).Truncate(size).Add(offset)
+}
+
+/*
+NewTumbling creates assigner for tumbling windows of given size.
+
+Windows are aligned like Time.Truncate and shifted by offset, for example a size of
+one day with an offset of 6 hours gives windows from 06:00 to 06:00. NewTumbling
+panics if size is not positive.
+*/
+func NewTumbling(size, offset datetime.Duration) Tumbling {
+	if size <= 0 {
+		panic("window: non-positive size for NewTumbling")
+	}
+
+	return Tumbling{size: size, offset: offset % size}
+}
+
+func (a Tumbling) Assign(t datetime.Time) []Window {
+	start := alignedStart(t, a.size, a.offset)
+	return []Window{{Start: start, End: start.Add(a.size)}}
+}
+
+/*
+NewHopping creates assigner for hopping windows of given size starting every slide.
+
+Each time belongs to about size / slide windows. Windows are aligned like in
+NewTumbling. NewHopping panics if size or slide is not positive.
+*/
+func NewHopping(size, slide, offset datetime.Duration) Hopping {
+	if size <= 0 || slide <= 0 {
+		panic("window: non-positive size or slide for NewHopping")
+	}
+
+	return Hopping{size: size, slide: slide, offset: offset % slide}
+}
+
+func (a Hopping) Assign(t datetime.Time) []Window {
+	last := alignedStart(t, a.slide, a.offset)
+
+	first := last
+	for first.Add(-a.slide).Add(a.size).After(t) {
+		first = first.Add(-a.slide)
+	}
+
+	var windows []Window
+	for start := first; !start.After(last); start = start.Add(a.slide) {
+		if end := start.Add(a.size); end.After(t) {
+			windows = append(windows, Window{Start: start, End: end})
+		}
+	}
+
+	return windows
+}
+
+/*
+NewSession creates assigner for session windows.
+
+Events closer than gap to each other end up in the same session. NewSession panics if
+gap is not positive.
+*/
+func NewSession(gap datetime.Duration) Session {
+	if gap <= 0 {
+		panic("window: non-positive gap for NewSession")
+	}
+
+	return Session{gap: gap}
+}
+
+func (a Session) Assign(t datetime.Time) []Window {
+	return []Window{{Start: t, End: t.Add(a.gap)}}
+}
+
+func (a Session) merging() {}
+
+// NewCalendar creates assigner for calendar periods, weeks start on Monday
+func NewCalendar(unit datetime.CalendarUnit) Calendar {
+	return Calendar{unit: unit, weekStart: time.Monday}
+}
+
+// NewCalendarWeek creates assigner for weeks starting on weekStart
+func NewCalendarWeek(weekStart datetime.Weekday) Calendar {
+	return Calendar{unit: datetime.UnitWeek, weekStart: weekStart}
+}
+
+func (a Calendar) Assign(t datetime.Time) []Window {
+	if a.unit == datetime.UnitWeek {
+		start := t.StartOfWeek(a.weekStart)
+		return []Window{{Start: start, End: start.AddDate(0, 0, daysPerWeek)}}
+	}
+
+	return []Window{{Start: t.StartOf(a.unit), End: t.EndOf(a.unit).Add(1)}}
+}
@@ -0,0 +1,296 @@
+package window_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ram-nad/go-utils/datetime"
+	"github.com/ram-nad/go-utils/datetime/window"
+)
+
+func at(hour, minute, second int) datetime.Time {
+	return datetime.Date(2021, 8, 19, hour, minute, second, 0)
+}
+
+func sum(acc, v int) int {
+	return acc + v
+}
+
+func TestTumbling(t *testing.T) {
+	tests := []struct {
+		size     datetime.Duration
+		offset   datetime.Duration
+		t        datetime.Time
+		expected window.Window
+	}{
+		{
+			datetime.Minutes(5),
+			0,
+			at(10, 7, 30),
+			window.Window{Start: at(10, 5, 0), End: at(10, 10, 0)},
+		},
+		{
+			datetime.Minutes(5),
+			0,
+			at(10, 5, 0),
+			window.Window{Start: at(10, 5, 0), End: at(10, 10, 0)},
+		},
+		{
+			datetime.Hours(24),
+			datetime.Hours(6),
+			at(3, 0, 0),
+			window.Window{
+				Start: datetime.Date(2021, 8, 18, 6, 0, 0, 0),
+				End:   at(6, 0, 0),
+			},
+		},
+		{
+			datetime.Hours(1),
+			datetime.Minutes(-15),
+			at(10, 50, 0),
+			window.Window{Start: at(10, 45, 0), End: at(11, 45, 0)},
+		},
+	}
+
+	for _, tc := range tests {
+		got := window.NewTumbling(tc.size, tc.offset).Assign(tc.t)
+		if len(got) != 1 || got[0] != tc.expected {
+			t.Errorf(
+				"Tumbling(%v, %v).Assign(%v) = %v, want %v",
+				tc.size,
+				tc.offset,
+				tc.t,
+				got,
+				tc.expected,
+			)
+		}
+	}
+}
+
+func TestHopping(t *testing.T) {
+	hopping := window.NewHopping(datetime.Minutes(10), datetime.Minutes(5), 0)
+
+	got := hopping.Assign(at(10, 7, 0))
+	expected := []window.Window{
+		{Start: at(10, 0, 0), End: at(10, 10, 0)},
+		{Start: at(10, 5, 0), End: at(10, 15, 0)},
+	}
+
+	if len(got) != len(expected) {
+		t.Fatalf("Assign() = %v, want %v", got, expected)
+	}
+
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Errorf("Assign()[%d] = %v, want %v", i, got[i], expected[i])
+		}
+	}
+
+	// Slide larger than size leaves gaps between windows
+	sparse := window.NewHopping(datetime.Minutes(1), datetime.Minutes(5), 0)
+	if got := sparse.Assign(at(10, 3, 0)); len(got) != 0 {
+		t.Errorf("Assign() = %v, want no windows", got)
+	}
+
+	for s := range 3600 {
+		ts := at(10, 0, s)
+		windows := hopping.Assign(ts)
+		if len(windows) != 2 {
+			t.Fatalf("Assign(%v) = %v, want 2 windows", ts, windows)
+		}
+
+		for _, w := range windows {
+			if !w.Contains(ts) {
+				t.Fatalf("Assign(%v) = %v, window doesn't contain time", ts, w)
+			}
+		}
+	}
+}
+
+func TestCalendar(t *testing.T) {
+	ts := datetime.Date(2021, 2, 17, 13, 0, 0, 0)
+
+	tests := []struct {
+		assigner window.Calendar
+		expected window.Window
+	}{
+		{
+			window.NewCalendar(datetime.UnitMonth),
+			window.Window{
+				Start: datetime.Date(2021, 2, 1, 0, 0, 0, 0),
+				End:   datetime.Date(2021, 3, 1, 0, 0, 0, 0),
+			},
+		},
+		{
+			window.NewCalendar(datetime.UnitWeek),
+			window.Window{
+				Start: datetime.Date(2021, 2, 15, 0, 0, 0, 0),
+				End:   datetime.Date(2021, 2, 22, 0, 0, 0, 0),
+			},
+		},
+		{
+			window.NewCalendarWeek(time.Sunday),
+			window.Window{
+				Start: datetime.Date(2021, 2, 14, 0, 0, 0, 0),
+				End:   datetime.Date(2021, 2, 21, 0, 0, 0, 0),
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		got := tc.assigner.Assign(ts)
+		if len(got) != 1 || got[0] != tc.expected {
+			t.Errorf("Assign(%v) = %v, want %v", ts, got, tc.expected)
+		}
+	}
+}
+
+func TestAggregatorTumbling(t *testing.T) {
+	agg := window.NewAggregator(
+		window.NewTumbling(datetime.Minutes(1), 0),
+		datetime.Seconds(10),
+		sum,
+	)
+
+	if got := agg.Add(at(10, 0, 5), 1); len(got) != 0 {
+		t.Fatalf("Add() = %v, want no closed windows", got)
+	}
+	agg.Add(at(10, 0, 50), 2)
+	agg.Add(at(10, 1, 5), 4)
+
+	// Late event still within allowed lateness
+	if got := agg.Add(at(10, 0, 59), 8); len(got) != 0 {
+		t.Fatalf("Add() = %v, want no closed windows", got)
+	}
+
+	got := agg.Add(at(10, 1, 10), 16)
+	if len(got) != 1 {
+		t.Fatalf("Add() = %v, want 1 closed window", got)
+	}
+
+	expected := window.Result[int]{
+		Window: window.Window{Start: at(10, 0, 0), End: at(10, 1, 0)},
+		Value:  11,
+		Count:  3,
+	}
+	if got[0] != expected {
+		t.Errorf("Add() = %+v, want %+v", got[0], expected)
+	}
+
+	// Window was already emitted, event is dropped
+	if got := agg.Add(at(10, 0, 30), 32); len(got) != 0 || agg.Dropped() != 1 {
+		t.Errorf("Add() = %v, Dropped() = %d, want dropped event", got, agg.Dropped())
+	}
+
+	if w := agg.Watermark(); !w.Equal(at(10, 1, 0)) {
+		t.Errorf("Watermark() = %v, want %v", w, at(10, 1, 0))
+	}
+
+	got = agg.Flush()
+	if len(got) != 1 || got[0].Value != 20 || got[0].Count != 2 {
+		t.Errorf("Flush() = %+v, want value 20 with 2 events", got)
+	}
+
+	if got := agg.Flush(); len(got) != 0 {
+		t.Errorf("Flush() = %v, want no windows", got)
+	}
+}
+
+func TestAggregatorLocations(t *testing.T) {
+	agg := window.NewAggregator(window.NewTumbling(datetime.Minutes(1), 0), 0, sum)
+
+	// The same instants in another Location belong to the same window
+	zone := time.FixedZone("IST", 5*3600+1800)
+	agg.Add(at(10, 0, 5), 1)
+	agg.Add(datetime.Time(time.Time(at(10, 0, 6)).In(zone)), 2)
+
+	got := agg.Flush()
+	if len(got) != 1 || got[0].Value != 3 || got[0].Count != 2 {
+		t.Errorf("Flush() = %+v, want one window with value 3", got)
+	}
+}
+
+func TestAggregatorHopping(t *testing.T) {
+	agg := window.NewAggregator(
+		window.NewHopping(datetime.Minutes(10), datetime.Minutes(5), 0),
+		0,
+		sum,
+	)
+
+	var got []window.Result[int]
+	for m := range 20 {
+		got = append(got, agg.Add(at(10, m, 0), 1)...)
+	}
+	got = append(got, agg.Advance(at(10, 20, 0))...)
+
+	if len(got) != 4 {
+		t.Fatalf("Advance() = %v, want 4 windows", got)
+	}
+
+	for i, expected := range []int{5, 10, 10, 10} {
+		if got[i].Value != expected {
+			t.Errorf("Advance()[%d] = %+v, want value %d", i, got[i], expected)
+		}
+	}
+
+	// Watermark never moves backwards
+	if agg.Advance(at(9, 0, 0)); !agg.Watermark().Equal(at(10, 20, 0)) {
+		t.Errorf("Watermark() = %v, want %v", agg.Watermark(), at(10, 20, 0))
+	}
+}
+
+func TestAggregatorSession(t *testing.T) {
+	agg := window.NewAggregator(
+		window.NewSession(datetime.Minutes(5)),
+		datetime.Minutes(10),
+		func(acc, v string) string { return acc + v },
+	)
+
+	agg.Add(at(10, 0, 0), "a")
+	agg.Add(at(10, 8, 0), "c")
+	// Out of order event joins both sessions
+	agg.Add(at(10, 4, 0), "b")
+	agg.Add(at(10, 20, 0), "d")
+
+	got := agg.Advance(at(11, 0, 0))
+	expected := []window.Result[string]{
+		{
+			Window: window.Window{Start: at(10, 0, 0), End: at(10, 13, 0)},
+			Value:  "abc",
+			Count:  3,
+		},
+		{
+			Window: window.Window{Start: at(10, 20, 0), End: at(10, 25, 0)},
+			Value:  "d",
+			Count:  1,
+		},
+	}
+
+	if len(got) != len(expected) {
+		t.Fatalf("Advance() = %+v, want %+v", got, expected)
+	}
+
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Errorf("Advance()[%d] = %+v, want %+v", i, got[i], expected[i])
+		}
+	}
+}
+
+func ExampleAggregator() {
+	agg := window.NewAggregator(
+		window.NewTumbling(datetime.Minutes(1), 0),
+		datetime.Seconds(10),
+		sum,
+	)
+
+	events := []datetime.Time{at(10, 0, 5), at(10, 0, 40), at(10, 1, 3), at(10, 1, 12)}
+	for _, e := range events {
+		for _, r := range agg.Add(e, 1) {
+			_, _ = fmt.Println(r.Window, r.Value)
+		}
+	}
+	// Output:
+	// [2021-08-19T10:00:00Z, 2021-08-19T10:01:00Z) 2
+}
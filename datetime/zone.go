@@ -0,0 +1,373 @@
+package datetime
+
+/*
+Conversion between UTC and wall clock time in a time zone.
+
+Time is always UTC, but people read and enter local wall times. Zone wraps an IANA
+time zone, LocalDateTime is a wall time without a zone. t.LocalIn(zone) gives the wall
+time of an instant and FormatIn formats it for display.
+
+Converting a wall time back to UTC is not always possible. When clocks move forward
+some wall times are skipped (a gap) and when they move back some happen twice (an
+overlap). In America/New_York 2021-03-14 02:30 didn't happen and 2021-11-07 01:30
+happened both at 05:30Z and at 06:30Z. LocalDateTime.In takes a policy for each:
+
+	ShiftForward    wall time is moved forward by length of the gap, 02:30 is 03:30 EDT
+	ErrorOnGap      ErrSkippedTime
+	PreferEarlier   first of the two instants, 01:30 EDT
+	PreferLater     second of the two instants, 01:30 EST
+	ErrorOnOverlap  ErrAmbiguousTime
+
+The tz database is embedded, so zones can be loaded on systems without zoneinfo files.
+The system database is still preferred when present.
+
+Like LocalDate, the zero LocalDateTime stands for no wall time, it is encoded as empty
+text and as NULL. Invalid wall times fail to encode with ErrOutOfRange.
+*/
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"time"
+	_ "time/tzdata" // Fallback for systems without zoneinfo files
+)
+
+var (
+	// ErrUnknownZone is returned when a time zone can't be loaded
+	ErrUnknownZone = errors.New("datetime: unknown time zone")
+	// ErrSkippedTime is returned for a wall time in a gap with ErrorOnGap policy
+	ErrSkippedTime = errors.New("datetime: wall time skipped in time zone")
+	// ErrAmbiguousTime is returned for a wall time in an overlap with ErrorOnOverlap
+	ErrAmbiguousTime = errors.New("datetime: wall time is ambiguous in time zone")
+)
+
+// Zone is a time zone, the zero value is UTC
+type Zone struct {
+	loc *time.Location
+}
+
+// LocalDateTime is a wall clock date and time without a time zone
+type LocalDateTime struct {
+	Date LocalDate
+	Time TimeOfDay
+}
+
+// GapPolicy decides how a wall time skipped by a transition is converted
+type GapPolicy int
+
+// OverlapPolicy decides how a wall time repeated by a transition is converted
+type OverlapPolicy int
+
+const (
+	// ShiftForward moves the wall time forward by length of the gap
+	ShiftForward GapPolicy = iota
+	// ErrorOnGap returns ErrSkippedTime
+	ErrorOnGap
+)
+
+const (
+	// PreferEarlier uses the earlier instant, with offset before the transition
+	PreferEarlier OverlapPolicy = iota
+	// PreferLater uses the later instant, with offset after the transition
+	PreferLater
+	// ErrorOnOverlap returns ErrAmbiguousTime
+	ErrorOnOverlap
+)
+
+// Transitions are assumed to be more than this apart
+const transitionSearch = 24 * time.Hour
+
+// String returns name of the policy
+func (p GapPolicy) String() string {
+	switch p {
+	case ShiftForward:
+		return "ShiftForward"
+	case ErrorOnGap:
+		return "ErrorOnGap"
+	default:
+		return fmt.Sprintf("GapPolicy(%d)", int(p))
+	}
+}
+
+// String returns name of the policy
+func (p OverlapPolicy) String() string {
+	switch p {
+	case PreferEarlier:
+		return "PreferEarlier"
+	case PreferLater:
+		return "PreferLater"
+	case ErrorOnOverlap:
+		return "ErrorOnOverlap"
+	default:
+		return fmt.Sprintf("OverlapPolicy(%d)", int(p))
+	}
+}
+
+// LoadZone returns the time zone with IANA name like "Europe/Berlin"
+func LoadZone(name string) (Zone, error) {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return Zone{}, fmt.Errorf("%w %q", ErrUnknownZone, name)
+	}
+	return Zone{loc: loc}, nil
+}
+
+// FixedZone returns a time zone that always has the offset from UTC
+func FixedZone(name string, offset Duration) Zone {
+	return Zone{loc: time.FixedZone(name, int(offset/Seconds(1)))}
+}
+
+// location returns the time.Location, UTC for the zero value
+func (z Zone) location() *time.Location {
+	if z.loc == nil {
+		return time.UTC
+	}
+	return z.loc
+}
+
+// String returns name of the zone
+func (z Zone) String() string {
+	return z.location().String()
+}
+
+// Offset returns abbreviation of the zone and its offset from UTC at t
+func (z Zone) Offset(t Time) (string, Duration) {
+	name, offset := time.Time(t).In(z.location()).Zone()
+	return name, Seconds(int64(offset))
+}
+
+// LocalIn returns the wall time in the zone at t
+func (t Time) LocalIn(z Zone) LocalDateTime {
+	_, offset := z.Offset(t)
+	return LocalDateTimeOf(t.Add(offset))
+}
+
+// FormatIn formats the wall time in the zone at t, offset and zone name are of zone
+func (t Time) FormatIn(z Zone, format PrintFormat) string {
+	return string(t.AppendFormatIn(nil, z, format))
+}
+
+// AppendFormatIn is like FormatIn but appends to dst and returns the extended buffer
+func (t Time) AppendFormatIn(dst []byte, z Zone, format PrintFormat) []byte {
+	return time.Time(t).In(z.location()).AppendFormat(dst, string(format))
+}
+
+// NewLocalDateTime returns the wall time, values are normalized like in Date
+func NewLocalDateTime(year, month, day, hour, minute, second, nsec int) LocalDateTime {
+	return LocalDateTimeOf(Date(year, month, day, hour, minute, second, nsec))
+}
+
+// LocalDateTimeOf returns the date and time of day of t in UTC
+func LocalDateTimeOf(t Time) LocalDateTime {
+	return LocalDateTime{Date: LocalDateOf(t), Time: TimeOfDayOf(t)}
+}
+
+/*
+ParseLocalDateTime parses a wall time in "2006-01-02T15:04:05.999999999" format. The
+separator can also be a space and seconds are optional, like in ParseTimeOfDay.
+
+Errors are either ErrInvalidSyntax or ErrOutOfRange.
+*/
+func ParseLocalDateTime(s string) (LocalDateTime, error) {
+	if len(s) <= localDateLen || s[localDateLen] != 'T' && s[localDateLen] != ' ' {
+		return LocalDateTime{}, ErrInvalidSyntax
+	}
+
+	date, err := ParseLocalDate(s[:localDateLen])
+	if err != nil {
+		return LocalDateTime{}, err
+	}
+
+	tod, err := ParseTimeOfDay(s[localDateLen+1:])
+	if err != nil {
+		return LocalDateTime{}, err
+	}
+
+	return LocalDateTime{Date: date, Time: tod}, nil
+}
+
+// IsValid reports whether both date and time of day are valid
+func (dt LocalDateTime) IsValid() bool {
+	return dt.Date.IsValid() && dt.Time.IsValid()
+}
+
+// IsZero reports whether the wall time is the zero value
+func (dt LocalDateTime) IsZero() bool {
+	return dt == LocalDateTime{}
+}
+
+// utc returns the Time in UTC with the same wall time
+func (dt LocalDateTime) utc() Time {
+	return dt.Date.At(dt.Time)
+}
+
+// String returns the wall time in "2006-01-02T15:04:05.999999999" format
+func (dt LocalDateTime) String() string {
+	return string(dt.AppendFormat(nil))
+}
+
+// AppendFormat appends the wall time in "2006-01-02T15:04:05.999999999" format to dst
+func (dt LocalDateTime) AppendFormat(dst []byte) []byte {
+	dst = dt.Date.AppendFormat(dst)
+	dst = append(dst, 'T')
+	return dt.Time.AppendFormat(dst)
+}
+
+// Add returns the wall time d later, ignoring any transitions
+func (dt LocalDateTime) Add(d Duration) LocalDateTime {
+	return LocalDateTimeOf(dt.utc().Add(d))
+}
+
+// Sub returns the duration dt-o between wall times, ignoring any transitions
+func (dt LocalDateTime) Sub(o LocalDateTime) Duration {
+	return dt.utc().Sub(o.utc())
+}
+
+// Compare returns -1, 0 or 1 when dt is before, equal to or after o
+func (dt LocalDateTime) Compare(o LocalDateTime) int {
+	if c := dt.Date.Compare(o.Date); c != 0 {
+		return c
+	}
+	return dt.Time.Compare(o.Time)
+}
+
+// Before reports whether dt is before o
+func (dt LocalDateTime) Before(o LocalDateTime) bool {
+	return dt.Compare(o) < 0
+}
+
+// After reports whether dt is after o
+func (dt LocalDateTime) After(o LocalDateTime) bool {
+	return dt.Compare(o) > 0
+}
+
+/*
+In returns the instant when clocks in the zone show the wall time.
+
+Wall times skipped or repeated by a transition are resolved by gap and overlap
+policies. An error is only returned for ErrorOnGap, ErrorOnOverlap or unknown
+policies.
+*/
+func (dt LocalDateTime) In(z Zone, gap GapPolicy, overlap OverlapPolicy) (Time, error) {
+	wall := dt.utc()
+
+	// Offsets in effect before and after any transition around the wall time
+	_, before := z.Offset(wall.Add(-Duration(transitionSearch)))
+	_, after := z.Offset(wall.Add(Duration(transitionSearch)))
+
+	earlier, okEarlier := resolveOffset(z, wall, before)
+	later, okLater := resolveOffset(z, wall, after)
+
+	switch {
+	case okEarlier && okLater && !earlier.Equal(later):
+		return resolveOverlap(earlier, later, overlap)
+	case okEarlier:
+		return earlier, nil
+	case okLater:
+		return later, nil
+	}
+
+	switch gap {
+	case ShiftForward:
+		// Offset before the transition moves the wall time past the gap
+		return wall.Add(-before), nil
+	case ErrorOnGap:
+		return Time{}, fmt.Errorf("%w: %v in %v", ErrSkippedTime, dt, z)
+	default:
+		return Time{}, fmt.Errorf("datetime: unknown gap policy %v", gap)
+	}
+}
+
+// resolveOffset returns the instant with the wall time, if zone has the offset then
+func resolveOffset(z Zone, wall Time, offset Duration) (Time, bool) {
+	t := wall.Add(-offset)
+	_, actual := z.Offset(t)
+	return t, actual == offset
+}
+
+func resolveOverlap(earlier, later Time, overlap OverlapPolicy) (Time, error) {
+	if later.Before(earlier) {
+		earlier, later = later, earlier
+	}
+
+	switch overlap {
+	case PreferEarlier:
+		return earlier, nil
+	case PreferLater:
+		return later, nil
+	case ErrorOnOverlap:
+		return Time{}, fmt.Errorf(
+			"%w: both %v and %v",
+			ErrAmbiguousTime,
+			earlier.ISOStringNano(),
+			later.ISOStringNano(),
+		)
+	default:
+		return Time{}, fmt.Errorf("datetime: unknown overlap policy %v", overlap)
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler, the zero value is empty text
+func (dt LocalDateTime) MarshalText() ([]byte, error) {
+	if dt.IsZero() {
+		return []byte{}, nil
+	}
+
+	if !dt.IsValid() || dt.Date.Year < 0 || dt.Date.Year > 9999 {
+		return nil, fmt.Errorf(
+			"datetime: encoding date and time %v: %w", dt, ErrOutOfRange,
+		)
+	}
+
+	return dt.AppendFormat(nil), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, empty text is the zero value
+func (dt *LocalDateTime) UnmarshalText(data []byte) error {
+	if len(data) == 0 {
+		*dt = LocalDateTime{}
+		return nil
+	}
+
+	parsed, err := ParseLocalDateTime(string(data))
+	if err != nil {
+		return fmt.Errorf("datetime: parsing date and time %q: %w", data, err)
+	}
+
+	*dt = parsed
+	return nil
+}
+
+// Value implements driver.Valuer, the zero value is NULL
+func (dt LocalDateTime) Value() (driver.Value, error) {
+	if dt.IsZero() {
+		return nil, nil
+	}
+
+	text, err := dt.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+
+	return string(text), nil
+}
+
+// Scan implements sql.Scanner, NULL is scanned as the zero value
+func (dt *LocalDateTime) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*dt = LocalDateTime{}
+		return nil
+	case time.Time:
+		// Fields in location of the value, drivers use it for wall times
+		_ = dt.Date.Scan(v)
+		return dt.Time.Scan(v)
+	case string:
+		return dt.UnmarshalText([]byte(v))
+	case []byte:
+		return dt.UnmarshalText(v)
+	default:
+		return fmt.Errorf("datetime: cannot scan %T into LocalDateTime", src)
+	}
+}
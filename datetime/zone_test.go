@@ -0,0 +1,336 @@
+package datetime_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ram-nad/go-utils/datetime"
+)
+
+func mustLoadZone(t testing.TB, name string) datetime.Zone {
+	t.Helper()
+
+	z, err := datetime.LoadZone(name)
+	if err != nil {
+		t.Fatalf("LoadZone(%q) = %v", name, err)
+	}
+	return z
+}
+
+func TestLoadZone(t *testing.T) {
+	z := mustLoadZone(t, "Asia/Kolkata")
+	if z.String() != "Asia/Kolkata" {
+		t.Errorf("String() = %q, want %q", z.String(), "Asia/Kolkata")
+	}
+
+	name, offset := z.Offset(datetime.Date(2021, 8, 9, 0, 0, 0, 0))
+	if name != "IST" || offset != datetime.Minutes(330) {
+		t.Errorf("Offset() = %s, %v, want IST, 5h30m", name, offset)
+	}
+
+	if _, err := datetime.LoadZone("Mars/Olympus_Mons"); !errors.Is(
+		err,
+		datetime.ErrUnknownZone,
+	) {
+		t.Errorf("LoadZone(Mars/Olympus_Mons) = %v, want ErrUnknownZone", err)
+	}
+
+	var utc datetime.Zone
+	if utc.String() != "UTC" {
+		t.Errorf("Zero Zone String() = %q, want UTC", utc.String())
+	}
+}
+
+func TestLocalDateTimeIn(t *testing.T) {
+	tests := []struct {
+		zone     string
+		wall     datetime.LocalDateTime
+		gap      datetime.GapPolicy
+		overlap  datetime.OverlapPolicy
+		expected datetime.Time
+		err      error
+	}{
+		// Regular wall times
+		{
+			zone:     "America/New_York",
+			wall:     datetime.NewLocalDateTime(2021, 7, 4, 12, 0, 0, 0),
+			expected: datetime.Date(2021, 7, 4, 16, 0, 0, 0),
+		},
+		{
+			zone:     "Asia/Kolkata",
+			wall:     datetime.NewLocalDateTime(2021, 8, 9, 21, 37, 3, 5),
+			expected: datetime.Date(2021, 8, 9, 16, 7, 3, 5),
+		},
+		// Spring forward gap
+		{
+			zone:     "America/New_York",
+			wall:     datetime.NewLocalDateTime(2021, 3, 14, 2, 30, 0, 0),
+			gap:      datetime.ShiftForward,
+			expected: datetime.Date(2021, 3, 14, 7, 30, 0, 0),
+		},
+		{
+			zone: "America/New_York",
+			wall: datetime.NewLocalDateTime(2021, 3, 14, 2, 30, 0, 0),
+			gap:  datetime.ErrorOnGap,
+			err:  datetime.ErrSkippedTime,
+		},
+		{
+			zone:     "America/New_York",
+			wall:     datetime.NewLocalDateTime(2021, 3, 14, 3, 0, 0, 0),
+			gap:      datetime.ErrorOnGap,
+			expected: datetime.Date(2021, 3, 14, 7, 0, 0, 0),
+		},
+		// Fall back overlap
+		{
+			zone:     "America/New_York",
+			wall:     datetime.NewLocalDateTime(2021, 11, 7, 1, 30, 0, 0),
+			overlap:  datetime.PreferEarlier,
+			expected: datetime.Date(2021, 11, 7, 5, 30, 0, 0),
+		},
+		{
+			zone:     "America/New_York",
+			wall:     datetime.NewLocalDateTime(2021, 11, 7, 1, 30, 0, 0),
+			overlap:  datetime.PreferLater,
+			expected: datetime.Date(2021, 11, 7, 6, 30, 0, 0),
+		},
+		{
+			zone:    "America/New_York",
+			wall:    datetime.NewLocalDateTime(2021, 11, 7, 1, 30, 0, 0),
+			overlap: datetime.ErrorOnOverlap,
+			err:     datetime.ErrAmbiguousTime,
+		},
+		// Half hour daylight saving time
+		{
+			zone:     "Australia/Lord_Howe",
+			wall:     datetime.NewLocalDateTime(2021, 4, 4, 1, 45, 0, 0),
+			overlap:  datetime.PreferLater,
+			expected: datetime.Date(2021, 4, 3, 15, 15, 0, 0),
+		},
+		// Samoa skipped the whole day of 2011-12-30
+		{
+			zone:     "Pacific/Apia",
+			wall:     datetime.NewLocalDateTime(2011, 12, 30, 12, 0, 0, 0),
+			expected: datetime.Date(2011, 12, 30, 22, 0, 0, 0),
+		},
+		{
+			zone: "Pacific/Apia",
+			wall: datetime.NewLocalDateTime(2011, 12, 30, 12, 0, 0, 0),
+			gap:  datetime.ErrorOnGap,
+			err:  datetime.ErrSkippedTime,
+		},
+	}
+
+	for _, tc := range tests {
+		z := mustLoadZone(t, tc.zone)
+		got, err := tc.wall.In(z, tc.gap, tc.overlap)
+
+		if !errors.Is(err, tc.err) || !got.Equal(tc.expected) {
+			t.Errorf(
+				"%v.In(%v, %v, %v) = %v, %v, want %v, %v",
+				tc.wall,
+				z,
+				tc.gap,
+				tc.overlap,
+				got,
+				err,
+				tc.expected,
+				tc.err,
+			)
+		}
+	}
+}
+
+func TestLocalInRoundTrip(t *testing.T) {
+	zones := []string{"America/New_York", "Europe/London", "Australia/Lord_Howe"}
+	for _, name := range zones {
+		z := mustLoadZone(t, name)
+		start := datetime.Date(2021, 1, 1, 0, 0, 0, 0)
+
+		for i := range 365 * 24 * 4 {
+			instant := start.Add(datetime.Minutes(int64(i) * 15))
+			wall := instant.LocalIn(z)
+
+			earlier, err1 := wall.In(z, datetime.ErrorOnGap, datetime.PreferEarlier)
+			later, err2 := wall.In(z, datetime.ErrorOnGap, datetime.PreferLater)
+			if err1 != nil || err2 != nil ||
+				!earlier.Equal(instant) && !later.Equal(instant) {
+				t.Fatalf(
+					"%s: %v.LocalIn() = %v, converted back to %v, %v (%v, %v)",
+					name,
+					instant,
+					wall,
+					earlier,
+					later,
+					err1,
+					err2,
+				)
+			}
+		}
+	}
+}
+
+func TestFormatIn(t *testing.T) {
+	dt := datetime.Date(2021, 11, 7, 6, 30, 0, 0)
+
+	tests := []struct {
+		zone     string
+		format   datetime.PrintFormat
+		expected string
+	}{
+		{"America/New_York", datetime.RFC3339, "2021-11-07T01:30:00-05:00"},
+		{"America/New_York", datetime.RFC1123, "Sun, 07 Nov 2021 01:30:00 EST"},
+		{"Europe/Berlin", datetime.RFC3339Milli, "2021-11-07T07:30:00.000+01:00"},
+		{"UTC", datetime.RFC3339, "2021-11-07T06:30:00Z"},
+	}
+
+	for _, tc := range tests {
+		z := mustLoadZone(t, tc.zone)
+		if got := dt.FormatIn(z, tc.format); got != tc.expected {
+			t.Errorf("FormatIn(%v, %q) = %q, want %q", z, tc.format, got, tc.expected)
+		}
+	}
+
+	fixed := datetime.FixedZone("XYZ", -datetime.Minutes(150))
+	if got := dt.FormatIn(fixed, "15:04 MST -07:00"); got != "04:00 XYZ -02:30" {
+		t.Errorf("FormatIn(fixed) = %q, want %q", got, "04:00 XYZ -02:30")
+	}
+}
+
+func TestParseLocalDateTime(t *testing.T) {
+	valid := []struct {
+		s        string
+		expected datetime.LocalDateTime
+	}{
+		{"2021-08-09T16:07:03", datetime.NewLocalDateTime(2021, 8, 9, 16, 7, 3, 0)},
+		{"2021-08-09 16:07", datetime.NewLocalDateTime(2021, 8, 9, 16, 7, 0, 0)},
+		{
+			"2021-08-09T16:07:03.25",
+			datetime.NewLocalDateTime(2021, 8, 9, 16, 7, 3, 250_000_000),
+		},
+	}
+
+	for _, tc := range valid {
+		got, err := datetime.ParseLocalDateTime(tc.s)
+		if err != nil || got != tc.expected {
+			t.Errorf(
+				"ParseLocalDateTime(%q) = %v, %v, want %v",
+				tc.s,
+				got,
+				err,
+				tc.expected,
+			)
+		}
+	}
+
+	invalid := []struct {
+		s   string
+		err error
+	}{
+		{"2021-08-09", datetime.ErrInvalidSyntax},
+		{"2021-08-09X16:07", datetime.ErrInvalidSyntax},
+		{"2021-08-09T16:07Z", datetime.ErrInvalidSyntax},
+		{"2021-02-30T16:07", datetime.ErrOutOfRange},
+		{"2021-08-09T24:00", datetime.ErrOutOfRange},
+	}
+
+	for _, tc := range invalid {
+		if _, err := datetime.ParseLocalDateTime(tc.s); !errors.Is(err, tc.err) {
+			t.Errorf("ParseLocalDateTime(%q) = %v, want %v", tc.s, err, tc.err)
+		}
+	}
+}
+
+func TestLocalDateTimeArithmetic(t *testing.T) {
+	dt := datetime.NewLocalDateTime(2021, 12, 31, 23, 30, 0, 0)
+
+	next := dt.Add(datetime.Hours(1))
+	expected := datetime.NewLocalDateTime(2022, 1, 1, 0, 30, 0, 0)
+	if next != expected {
+		t.Errorf("Add(1h) = %v, want %v", next, expected)
+	}
+
+	if d := next.Sub(dt); d != datetime.Hours(1) {
+		t.Errorf("Sub() = %v, want 1h", d)
+	}
+
+	if !dt.Before(next) || dt.After(next) || dt.Compare(dt) != 0 {
+		t.Errorf("Comparison of %v and %v is wrong", dt, next)
+	}
+
+	text, _ := dt.MarshalText()
+
+	var decoded datetime.LocalDateTime
+	if err := decoded.UnmarshalText(text); err != nil || decoded != dt {
+		t.Errorf("UnmarshalText(%s) = %v, %v, want %v", text, decoded, err, dt)
+	}
+}
+
+func TestLocalDateTimeZeroRoundTrip(t *testing.T) {
+	type event struct {
+		Starts datetime.LocalDateTime `json:"starts"`
+	}
+
+	data, err := json.Marshal(event{})
+	expected := `{"starts":""}`
+	if err != nil || string(data) != expected {
+		t.Fatalf("json.Marshal() = %s, %v, want %s", data, err, expected)
+	}
+
+	decoded := event{Starts: datetime.NewLocalDateTime(2021, 8, 9, 10, 0, 0, 0)}
+	if err := json.Unmarshal(data, &decoded); err != nil || decoded != (event{}) {
+		t.Errorf("json.Unmarshal() = %+v, %v, want zero value", decoded, err)
+	}
+
+	// NULL and the zero value map to each other
+	var dt datetime.LocalDateTime
+	v, err := dt.Value()
+	if err != nil || v != nil {
+		t.Errorf("Value() = %v, %v, want nil", v, err)
+	}
+
+	dt = datetime.NewLocalDateTime(2021, 8, 9, 10, 0, 0, 0)
+	if err := dt.Scan(v); err != nil || !dt.IsZero() {
+		t.Errorf("Scan(%v) = %v, %v, want zero value", v, dt, err)
+	}
+}
+
+func TestLocalDateTimeInvalidEncoding(t *testing.T) {
+	for _, dt := range []datetime.LocalDateTime{
+		{Date: datetime.LocalDate{Year: 2021, Month: time.February, Day: 30}},
+		{Date: datetime.LocalDate{Year: 10000, Month: time.January, Day: 1}},
+		{
+			Date: datetime.LocalDate{Year: 2021, Month: time.August, Day: 9},
+			Time: datetime.TimeOfDay{Hour: 24},
+		},
+		{Time: datetime.TimeOfDay{Hour: 12}},
+	} {
+		if _, err := dt.MarshalText(); !errors.Is(err, datetime.ErrOutOfRange) {
+			t.Errorf("MarshalText() of %v = %v, want ErrOutOfRange", dt, err)
+		}
+
+		if _, err := dt.Value(); !errors.Is(err, datetime.ErrOutOfRange) {
+			t.Errorf("Value() of %v = %v, want ErrOutOfRange", dt, err)
+		}
+	}
+}
+
+func ExampleLocalDateTime_In() {
+	z, _ := datetime.LoadZone("Europe/Berlin")
+
+	// Clocks moved from 02:00 to 03:00
+	wall := datetime.NewLocalDateTime(2021, 3, 28, 2, 30, 0, 0)
+	t, _ := wall.In(z, datetime.ShiftForward, datetime.PreferEarlier)
+	_, _ = fmt.Println(t, t.LocalIn(z))
+
+	_, err := wall.In(z, datetime.ErrorOnGap, datetime.ErrorOnOverlap)
+	_, _ = fmt.Println(err)
+
+	_, _ = fmt.Println(t.FormatIn(z, datetime.RFC1123Z))
+	// Output:
+	// Sun, 28 Mar 2021 01:30:00 UTC 2021-03-28T03:30:00
+	// datetime: wall time skipped in time zone: 2021-03-28T02:30:00 in Europe/Berlin
+	// Sun, 28 Mar 2021 03:30:00 +0200
+}